@@ -2,9 +2,12 @@ package abad
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 
 	"github.com/NeowayLabs/abad/ast"
 	"github.com/NeowayLabs/abad/builtins"
+	"github.com/NeowayLabs/abad/envrec"
 	"github.com/NeowayLabs/abad/internal/utf16"
 	"github.com/NeowayLabs/abad/parser"
 	"github.com/NeowayLabs/abad/token"
@@ -15,11 +18,64 @@ type (
 	// Abad interpreter, a very bad one.
 	Abad struct {
 		global *types.DataObject
+		ctx    context // running execution context
+
+		// objectProto is the Object prototype object.
+		objectProto *types.DataObject
+
+		// fnProto is the Function prototype object.
+		fnProto *types.UserFunction
+
+		// arrayProto is the Array prototype object.
+		arrayProto *types.Array
+
+		// depth is the number of function calls being executed.
+		depth int
+	}
+
+	// context is an execution context, it keeps track of the
+	// environments where identifiers are resolved and where
+	// variables are declared.
+	// http://es5.github.io/#x10.3
+	context struct {
+		lexEnv *envrec.LexEnv
+		varEnv *envrec.LexEnv
+		this   types.Value
+
+		// strict tells if the running code is strict mode code.
+		strict bool
+	}
+
+	// reference is the result of evaluating identifiers and property
+	// accessors, it resolves where a value is read from and written to.
+	// The base is an environment record (identifiers) or a value
+	// (properties), when both are nil the name could not be resolved.
+	// http://es5.github.io/#x8.7
+	reference struct {
+		env  envrec.Env
+		base types.Value
+		name utf16.Str
 	}
 )
 
 var (
-	consoleAttr = utf16.S("console")
+	consoleAttr   = utf16.S("console")
+	arrayAttr     = utf16.S("Array")
+	lengthAttr    = utf16.S("length")
+	prototypeAttr = utf16.S("prototype")
+	argumentsAttr = utf16.S("arguments")
+)
+
+// maxCallDepth limits the nested function calls, past it a
+// RangeError is thrown instead of exhausting the Go stack.
+const maxCallDepth = 10000
+
+// messages of the ReferenceError raised when the target of an
+// assignment or update expression is not a reference.
+const (
+	errInvalidAssignTarget  = "Invalid left-hand side in assignment"
+	errInvalidPrefixTarget  = "Invalid left-hand side expression in prefix operation"
+	errInvalidPostfixTarget = "Invalid left-hand side expression in postfix operation"
 )
 
 // NewAbad creates a new ecma script evaluator.
@@ -43,84 +99,539 @@ func (a *Abad) EvalFile(filename string, code string) (types.Value, error) {
 }
 
 func (a *Abad) eval(n ast.Node) (types.Value, error) {
-	if ast.IsExpr(n) {
-		return a.evalExpr(n)
+	if n.Type() == ast.NodeProgram {
+		return a.evalProgram(n.(*ast.Program))
 	}
 
-	var ret types.Value
-	var err error
+	c, err := a.execStmt(n)
+	return c.value, err
+}
 
-	switch n.Type() {
-	case ast.NodeProgram:
-		ret, err = a.evalProgram(n.(*ast.Program))
-	default:
-		panic(fmt.Sprintf("AST(%s) not implemented", n))
+func (a *Abad) setup() error {
+	objectProto, fnProto, err := builtins.NewObjectPrototype()
+	if err != nil {
+		return err
 	}
 
-	return ret, err
-}
+	arrayProto, err := builtins.NewArrayPrototype(objectProto, fnProto)
+	if err != nil {
+		return err
+	}
 
-func (a *Abad) setup() error {
-	console, err := builtins.NewConsole()
+	array, err := builtins.NewArrayConstructor(arrayProto, fnProto)
 	if err != nil {
 		return err
 	}
 
-	global := types.NewBaseDataObject()
-	err = global.Put(consoleAttr, console, true)
+	console, err := builtins.NewConsole(fnProto)
 	if err != nil {
 		return err
 	}
 
+	global := types.NewBaseDataObject()
+	for _, prop := range []struct {
+		name  utf16.Str
+		value types.Value
+	}{
+		{name: consoleAttr, value: console},
+		{name: arrayAttr, value: array},
+	} {
+		err = global.Put(prop.name, prop.value, true)
+		if err != nil {
+			return err
+		}
+	}
+
 	a.global = global
+	a.objectProto = objectProto
+	a.fnProto = fnProto
+	a.arrayProto = arrayProto
+	// http://es5.github.io/#x10.4.1.1
+	env := envrec.NewObjectLexEnv(global, nil, false)
+	a.ctx = context{
+		lexEnv: env,
+		varEnv: env,
+		this:   global,
+	}
 	return nil
 }
 
+// evalProgram evaluates the statements of stmts, the result is
+// the value of the last statement that produced a value.
+// http://es5.github.io/#x14
 func (a *Abad) evalProgram(stmts *ast.Program) (types.Value, error) {
-	var (
-		result types.Value
-		err    error
+	a.ctx.strict = stmts.Strict
+
+	err := a.instantiateDecls(stmts.Nodes, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := a.execStmts(stmts.Nodes)
+	return c.value, err
+}
+
+// instantiateDecls creates the bindings for the parameters of fn (if
+// any), and the functions and variables declared in code before it's
+// executed (also known as hoisting). Variables already bound keep
+// their values. In function code, arguments is bound to the arguments
+// object unless a parameter or function declaration uses the name.
+// http://es5.github.io/#x10.5
+func (a *Abad) instantiateDecls(
+	code []ast.Node, fn *types.UserFunction, args []types.Value,
+) error {
+	env := a.ctx.varEnv.Rec()
+
+	if fn != nil {
+		for i, name := range fn.Params() {
+			var val types.Value = types.Undefined
+			if i < len(args) {
+				val = args[i]
+			}
+
+			if !env.Has(name) {
+				err := env.New(name, false)
+				if err != nil {
+					return err
+				}
+			}
+
+			err := env.Set(name, val, false)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err := a.declareFunctions(code)
+	if err != nil {
+		return err
+	}
+
+	if fn != nil && !env.Has(argumentsAttr) {
+		err := a.declareArguments(fn, args)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, name := range varNames(code) {
+		if env.Has(name) {
+			continue
+		}
+
+		err := env.New(name, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// declareArguments binds arguments to the arguments object of the
+// call of fn. In strict mode code the binding is immutable.
+// http://es5.github.io/#x10.6
+func (a *Abad) declareArguments(fn *types.UserFunction, args []types.Value) error {
+	env := a.ctx.varEnv.Rec()
+
+	if !fn.Strict() {
+		argsobj := types.NewArguments(a.objectProto, fn, fn.Params(), args, env)
+
+		err := env.New(argumentsAttr, false)
+		if err != nil {
+			return err
+		}
+
+		return env.Set(argumentsAttr, argsobj, false)
+	}
+
+	decl, ok := env.(*envrec.Decl)
+	if !ok {
+		return fmt.Errorf("internal error: invalid function environment")
+	}
+
+	err := decl.NewImmutable(argumentsAttr)
+	if err != nil {
+		return err
+	}
+
+	return decl.InitImmutable(argumentsAttr,
+		types.NewStrictArguments(a.objectProto, args))
+}
+
+// declareFunctions binds the functions declared in stmts
+// in the variable environment.
+func (a *Abad) declareFunctions(stmts []ast.Node) error {
+	env := a.ctx.varEnv.Rec()
+
+	for _, stmt := range stmts {
+		if stmt.Type() != ast.NodeFunDecl {
+			continue
+		}
+
+		decl := stmt.(*ast.FunDecl)
+		name := utf16.Str(decl.Name)
+		if !env.Has(name) {
+			err := env.New(name, false)
+			if err != nil {
+				return err
+			}
+		}
+
+		fn := a.newFunction(decl.Args, decl.Body, a.ctx.lexEnv)
+		err := env.Set(name, fn, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// varNames returns the names declared by the var statements of
+// stmts, including the ones nested in other statements.
+func varNames(stmts []ast.Node) []utf16.Str {
+	var names []utf16.Str
+
+	for _, stmt := range stmts {
+		switch stmt.Type() {
+		case ast.NodeVarDecls:
+			for _, decl := range stmt.(ast.VarDecls) {
+				names = append(names, utf16.Str(decl.Name))
+			}
+		case ast.NodeBlockStmt:
+			block := stmt.(*ast.BlockStmt)
+			names = append(names, varNames(block.Nodes)...)
+		case ast.NodeIfStmt:
+			ifstmt := stmt.(*ast.IfStmt)
+			names = append(names, varNames([]ast.Node{ifstmt.Then})...)
+			if ifstmt.Else != nil {
+				names = append(names, varNames([]ast.Node{ifstmt.Else})...)
+			}
+		case ast.NodeWhileStmt:
+			loop := stmt.(*ast.WhileStmt)
+			names = append(names, varNames([]ast.Node{loop.Body})...)
+		case ast.NodeDoWhileStmt:
+			loop := stmt.(*ast.DoWhileStmt)
+			names = append(names, varNames([]ast.Node{loop.Body})...)
+		case ast.NodeForStmt:
+			loop := stmt.(*ast.ForStmt)
+			if loop.Init != nil {
+				names = append(names, varNames([]ast.Node{loop.Init})...)
+			}
+			names = append(names, varNames([]ast.Node{loop.Body})...)
+		case ast.NodeForInStmt:
+			loop := stmt.(*ast.ForInStmt)
+			names = append(names, varNames([]ast.Node{loop.Target, loop.Body})...)
+		case ast.NodeSwitchStmt:
+			for _, clause := range stmt.(*ast.SwitchStmt).Cases {
+				names = append(names, varNames(clause.Body)...)
+			}
+		case ast.NodeTryStmt:
+			try := stmt.(*ast.TryStmt)
+			for _, block := range []*ast.BlockStmt{try.Body, try.Catch, try.Finally} {
+				if block != nil {
+					names = append(names, varNames(block.Nodes)...)
+				}
+			}
+		case ast.NodeLabeledStmt:
+			labeled := stmt.(*ast.LabeledStmt)
+			names = append(names, varNames([]ast.Node{labeled.Body})...)
+		}
+	}
+
+	return names
+}
+
+// newFunction creates a function object closed over scope.
+// http://es5.github.io/#x13.2
+func (a *Abad) newFunction(
+	args []ast.Ident, body *ast.Program, scope *envrec.LexEnv,
+) *types.UserFunction {
+	params := make([]utf16.Str, len(args))
+	for i, arg := range args {
+		params[i] = utf16.Str(arg)
+	}
+
+	return types.NewUserFunction(params, body, body.Strict, a.fnProto,
+		func(f *types.UserFunction, this types.Object, args []types.Value) (types.Value, error) {
+			return a.callFunction(f, scope, this, args)
+		},
 	)
-	for _, node := range stmts.Nodes {
-		result, err = a.eval(node)
+}
+
+// evalFunExpr creates the function object of a function expression.
+// The name of named function expressions is bound in an environment
+// visible only by the function itself.
+// http://es5.github.io/#x13
+func (a *Abad) evalFunExpr(expr *ast.FunExpr) (types.Value, error) {
+	if len(expr.Name) == 0 {
+		return a.newFunction(expr.Args, expr.Body, a.ctx.lexEnv), nil
+	}
+
+	name := utf16.Str(expr.Name)
+	funcEnv := envrec.NewDeclLexEnv(a.ctx.lexEnv)
+	rec := funcEnv.Rec().(*envrec.Decl)
+
+	err := rec.NewImmutable(name)
+	if err != nil {
+		return nil, err
+	}
+
+	closure := a.newFunction(expr.Args, expr.Body, funcEnv)
+	return closure, rec.InitImmutable(name, closure)
+}
+
+// evalObjectLiteral creates a new object defining the properties in
+// the source order, the duplicated ones were validated by the parser.
+// http://es5.github.io/#x11.1.5
+func (a *Abad) evalObjectLiteral(expr *ast.ObjectLiteral) (types.Value, error) {
+	obj := types.NewDataObject(a.objectProto)
+
+	for _, prop := range expr.Props {
+		var desc *types.PropertyDescriptor
+
+		switch prop.Kind {
+		case ast.PropInit:
+			val, err := a.evalExpr(prop.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			desc = types.NewDataPropDesc(val, true, true, true)
+		case ast.PropGet, ast.PropSet:
+			fn := prop.Value.(*ast.FunExpr)
+			closure := a.newFunction(fn.Args, fn.Body, a.ctx.lexEnv)
+
+			desc = types.NewGenericPropDesc()
+			if prop.Kind == ast.PropGet {
+				desc.SetGet(closure)
+			} else {
+				desc.SetSet(closure)
+			}
+
+			desc.SetEnum(true)
+			desc.SetCfg(true)
+		}
+
+		_, err := obj.DefineOwnPropertyP(prop.Name, desc, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return obj, nil
+}
+
+// http://es5.github.io/#x11.1.4
+func (a *Abad) evalArrayLiteral(expr *ast.ArrayLiteral) (types.Value, error) {
+	arr := types.NewArray(a.arrayProto)
+
+	for i, elem := range expr.Elems {
+		if elem == nil {
+			continue
+		}
+
+		val, err := a.evalExpr(elem)
 		if err != nil {
 			return nil, err
 		}
+
+		_, err = arr.DefineOwnPropertyP(
+			utf16.S(strconv.Itoa(i)),
+			types.NewDataPropDesc(val, true, true, true),
+			false,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the trailing holes are counted in the length
+	err := arr.Put(lengthAttr, types.NewNumber(float64(len(expr.Elems))), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return arr, nil
+}
+
+// callFunction executes the code of f in a new execution context,
+// whose environment is enclosed by scope, where f was defined.
+// http://es5.github.io/#x10.4.3
+// http://es5.github.io/#x13.2.1
+func (a *Abad) callFunction(
+	f *types.UserFunction, scope *envrec.LexEnv, this types.Object, args []types.Value,
+) (types.Value, error) {
+	if a.depth >= maxCallDepth {
+		return nil, types.NewRangeError("Maximum call stack size exceeded")
+	}
+
+	saved := a.ctx
+	a.depth++
+	defer func() {
+		a.ctx = saved
+		a.depth--
+	}()
+
+	// undefined (nil) is replaced by the global object
+	// in non-strict code.
+	var thisval types.Value = this
+	if this == nil {
+		thisval = types.Undefined
+		if !f.Strict() {
+			thisval = a.global
+		}
 	}
 
-	return result, nil
+	env := envrec.NewDeclLexEnv(scope)
+	a.ctx = context{
+		lexEnv: env,
+		varEnv: env,
+		this:   thisval,
+		strict: f.Strict(),
+	}
+
+	code := f.Body().Nodes
+
+	err := a.instantiateDecls(code, f, args)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := a.execStmts(code)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.typ == completionReturn {
+		return c.value, nil
+	}
+
+	return types.Undefined, nil
 }
 
 func (a *Abad) evalUnaryExpr(expr *ast.UnaryExpr) (types.Value, error) {
 	op := expr.Operator
-	obj, err := a.eval(expr.Operand)
+
+	switch op {
+	case token.TypeOf:
+		return a.evalTypeOf(expr.Operand)
+	case token.Delete:
+		return a.evalDelete(expr.Operand)
+	}
+
+	obj, err := a.evalExpr(expr.Operand)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO(i4k): UnaryExpr could work in any expression in js
-	// examples below are valid:
-	//   -[]
-	//	 +[]
-	//   -{}
-	//   +{}
-	//   -eval("0")
-	//   -new Object()
-	num, ok := obj.(types.Number)
-	if !ok {
-		return nil, fmt.Errorf("not a number: %s", obj)
+	switch op {
+	case token.Void:
+		// http://es5.github.io/#x11.4.2
+		return types.Undefined, nil
+	case token.LNot:
+		// http://es5.github.io/#x11.4.9
+		return !obj.ToBool(), nil
+	}
+
+	num, err := types.ToNumber(obj)
+	if err != nil {
+		return nil, err
 	}
 
 	switch op {
 	case token.Minus:
-		num = -num
+		// http://es5.github.io/#x11.4.7
+		return -num, nil
 	case token.Plus:
-		num = +num
-	default:
-		return nil, fmt.Errorf("unsupported unary operator: %s", op)
+		// http://es5.github.io/#x11.4.6
+		return num, nil
+	case token.Not:
+		// http://es5.github.io/#x11.4.8
+		return types.Number(^num.ToInt32()), nil
+	}
+
+	return nil, fmt.Errorf("unsupported unary operator: %s", op)
+}
+
+// evalTypeOf evaluates the typeof operator, unresolvable
+// identifiers are undefined instead of a ReferenceError.
+// http://es5.github.io/#x11.4.3
+func (a *Abad) evalTypeOf(operand ast.Node) (types.Value, error) {
+	var (
+		val types.Value
+		err error
+	)
+
+	if operand.Type() == ast.NodeIdent {
+		ref := a.identRef(operand.(ast.Ident))
+		if ref.env == nil {
+			return types.NewString("undefined"), nil
+		}
+
+		val, err = a.getValue(ref)
+	} else {
+		val, err = a.evalExpr(operand)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := val.(types.Function); ok {
+		return types.NewString("function"), nil
+	}
+
+	switch val.Kind() {
+	case types.KindNull:
+		return types.NewString("object"), nil
+	case types.KindBool:
+		return types.NewString("boolean"), nil
+	}
+
+	return types.NewString(val.Kind().String()), nil
+}
+
+// evalDelete evaluates the delete operator. Deleting a value
+// that is not a reference, or an unresolvable name, succeeds.
+// http://es5.github.io/#x11.4.1
+func (a *Abad) evalDelete(operand ast.Node) (types.Value, error) {
+	switch operand.Type() {
+	case ast.NodeIdent:
+		ref := a.identRef(operand.(ast.Ident))
+		if ref.env == nil {
+			return types.True, nil
+		}
+
+		return types.Bool(ref.env.Del(ref.name)), nil
+	case ast.NodeMemberExpr:
+		ref, err := a.memberRef(operand.(*ast.MemberExpr))
+		if err != nil {
+			return nil, err
+		}
+
+		if ref.base.Kind() != types.KindObject {
+			// properties of primitive values are not observable.
+			return types.True, nil
+		}
+
+		obj, err := ref.base.ToObject()
+		if err != nil {
+			return nil, err
+		}
+
+		ok, err := obj.Delete(ref.name, a.ctx.strict)
+		return types.Bool(ok), err
+	}
+
+	_, err := a.evalExpr(operand)
+	if err != nil {
+		return nil, err
 	}
 
-	return num, nil
+	return types.True, nil
 }
 
 func (a *Abad) evalExpr(n ast.Node) (types.Value, error) {
@@ -145,75 +656,742 @@ func (a *Abad) evalExpr(n ast.Node) (types.Value, error) {
 	case ast.NodeIdent:
 		val := n.(ast.Ident)
 		return a.evalIdentExpr(val)
+	case ast.NodeThis:
+		return a.ctx.this, nil
 	case ast.NodeMemberExpr:
 		val := n.(*ast.MemberExpr)
 		return a.evalMemberExpr(val)
 	case ast.NodeCallExpr:
 		val := n.(*ast.CallExpr)
 		return a.evalCallExpr(val)
+	case ast.NodeNewExpr:
+		expr := n.(*ast.NewExpr)
+		return a.evalNewExpr(expr)
 	case ast.NodeUnaryExpr:
 		expr := n.(*ast.UnaryExpr)
 		return a.evalUnaryExpr(expr)
+	case ast.NodeUpdateExpr:
+		expr := n.(*ast.UpdateExpr)
+		return a.evalUpdateExpr(expr)
+	case ast.NodeBinaryExpr:
+		expr := n.(*ast.BinaryExpr)
+		return a.evalBinaryExpr(expr)
+	case ast.NodeAssignExpr:
+		expr := n.(*ast.AssignExpr)
+		return a.evalAssignExpr(expr)
+	case ast.NodeConditionalExpr:
+		expr := n.(*ast.ConditionalExpr)
+		return a.evalConditionalExpr(expr)
+	case ast.NodeSequenceExpr:
+		expr := n.(*ast.SequenceExpr)
+		return a.evalSequenceExpr(expr)
+	case ast.NodeFunExpr:
+		expr := n.(*ast.FunExpr)
+		return a.evalFunExpr(expr)
+	case ast.NodeObjectLiteral:
+		expr := n.(*ast.ObjectLiteral)
+		return a.evalObjectLiteral(expr)
+	case ast.NodeArrayLiteral:
+		expr := n.(*ast.ArrayLiteral)
+		return a.evalArrayLiteral(expr)
 	default:
 		return nil, fmt.Errorf("unknown node type: %v", n)
 	}
 }
 
-func (a *Abad) evalIdentExpr(ident ast.Ident) (types.Value, error) {
-	val, err := a.global.Get(utf16.Str(ident))
+func (a *Abad) evalBinaryExpr(expr *ast.BinaryExpr) (types.Value, error) {
+	if expr.Operator == token.LAnd || expr.Operator == token.LOr {
+		return a.evalLogicalExpr(expr)
+	}
+
+	lval, err := a.evalExpr(expr.Left)
+	if err != nil {
+		return nil, err
+	}
+
+	rval, err := a.evalExpr(expr.Right)
 	if err != nil {
 		return nil, err
 	}
 
-	if types.StrictEqual(val, types.Undefined) {
-		return nil, fmt.Errorf("[%s] is not defined",
-			ident.String())
+	return binaryOp(expr.Operator, lval, rval)
+}
+
+// toNumbers converts the operands of a binary operator to numbers,
+// the left one first.
+func toNumbers(lval, rval types.Value) (types.Number, types.Number, error) {
+	lnum, err := types.ToNumber(lval)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	rnum, err := types.ToNumber(rval)
+	return lnum, rnum, err
+}
+
+// binaryOp applies the non short-circuit binary operator op
+// to the already evaluated operands.
+func binaryOp(op token.Type, lval, rval types.Value) (types.Value, error) {
+	switch op {
+	case token.Plus:
+		return add(lval, rval)
+	case token.Minus, token.Mul, token.Quo, token.Rem:
+		lnum, rnum, err := toNumbers(lval, rval)
+		if err != nil {
+			return nil, err
+		}
+		return arith(op, lnum, rnum), nil
+	case token.LShift, token.RShift, token.RShiftZero,
+		token.And, token.Or, token.Xor:
+		lnum, rnum, err := toNumbers(lval, rval)
+		if err != nil {
+			return nil, err
+		}
+		return bitwise(op, lnum, rnum), nil
+	case token.Less, token.Greater, token.LessEq, token.GreaterEq:
+		return compare(op, lval, rval)
+	case token.Equal, token.NotEqual:
+		eq, err := equal(lval, rval)
+		if err != nil {
+			return nil, err
+		}
+		return types.Bool(eq == (op == token.Equal)), nil
+	case token.TEqual:
+		return types.Bool(types.StrictEqual(lval, rval)), nil
+	case token.NotTEqual:
+		return types.Bool(!types.StrictEqual(lval, rval)), nil
+	case token.In:
+		return in(lval, rval)
+	case token.InstanceOf:
+		return instanceOf(lval, rval)
+	}
+
+	return nil, fmt.Errorf("unsupported binary operator: %s", op)
+}
+
+// in tells if the object rval has the property named lval.
+// http://es5.github.io/#x11.8.7
+func in(lval, rval types.Value) (types.Value, error) {
+	if rval.Kind() != types.KindObject {
+		return nil, types.NewTypeError(
+			"Cannot use 'in' operator to search for '%s' in %s",
+			lval.ToString(), rval.ToString())
+	}
+
+	name, err := lval.ToPrimitive(types.KindString)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := rval.(types.Object)
+	return types.Bool(obj.HasProperty(utf16.Str(name.ToString()))), nil
+}
+
+// instanceOf tells if the prototype property of the function
+// rval is in the prototype chain of lval ([[HasInstance]]).
+// http://es5.github.io/#x11.8.6
+// http://es5.github.io/#x15.3.5.3
+func instanceOf(lval, rval types.Value) (types.Value, error) {
+	if rval.Kind() != types.KindObject {
+		return nil, types.NewTypeError(
+			"Right-hand side of 'instanceof' is not an object")
+	}
+
+	fn, ok := rval.(types.Function)
+	if !ok {
+		return nil, types.NewTypeError(
+			"Right-hand side of 'instanceof' is not callable")
+	}
+
+	obj, ok := lval.(types.Object)
+	if !ok {
+		return types.False, nil
+	}
+
+	proto, err := fn.Get(prototypeAttr)
+	if err != nil {
+		return nil, err
+	}
+
+	if proto.Kind() != types.KindObject {
+		return nil, types.NewTypeError(
+			"Function has non-object prototype '%s' in instanceof check",
+			proto.ToString())
+	}
+
+	for {
+		next, ok := obj.Prototype().(types.Object)
+		if !ok {
+			return types.False, nil
+		}
+
+		if types.StrictEqual(next, proto) {
+			return types.True, nil
+		}
+
+		obj = next
+	}
+}
+
+// evalLogicalExpr evaluates && and || expressions. The right operand
+// is only evaluated if the left one does not determine the result,
+// and the result is the value of the last evaluated operand (not
+// necessarily a boolean).
+// http://es5.github.io/#x11.11
+func (a *Abad) evalLogicalExpr(expr *ast.BinaryExpr) (types.Value, error) {
+	lval, err := a.evalExpr(expr.Left)
+	if err != nil {
+		return nil, err
+	}
+
+	if lval.IsTrue() == (expr.Operator == token.LOr) {
+		return lval, nil
+	}
+
+	return a.evalExpr(expr.Right)
+}
+
+// http://es5.github.io/#x11.12
+func (a *Abad) evalConditionalExpr(expr *ast.ConditionalExpr) (types.Value, error) {
+	cond, err := a.evalExpr(expr.Cond)
+	if err != nil {
+		return nil, err
+	}
+
+	if cond.ToBool() {
+		return a.evalExpr(expr.Then)
+	}
+
+	return a.evalExpr(expr.Else)
+}
+
+// evalSequenceExpr evaluates the expressions from left to
+// right, the value is the value of the last one.
+// http://es5.github.io/#x11.14
+func (a *Abad) evalSequenceExpr(expr *ast.SequenceExpr) (types.Value, error) {
+	var (
+		val types.Value
+		err error
+	)
+
+	for _, e := range expr.Exprs {
+		val, err = a.evalExpr(e)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return val, nil
 }
 
-func (a *Abad) evalMemberExpr(member *ast.MemberExpr) (types.Value, error) {
-	objval, err := a.evalExpr(member.Object)
+// add implements the addition operator, that concatenates
+// strings or sums numbers.
+// http://es5.github.io/#x11.6.1
+func add(lval, rval types.Value) (types.Value, error) {
+	lprim, err := lval.ToPrimitive(types.KindNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	rprim, err := rval.ToPrimitive(types.KindNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if lprim.Kind() == types.KindString ||
+		rprim.Kind() == types.KindString {
+		return lprim.ToString().Concat(rprim.ToString()), nil
+	}
+
+	return lprim.ToNumber() + rprim.ToNumber(), nil
+}
+
+// arith applies the numeric operator op to numbers lnum and rnum.
+// Go floating point arithmetic follows IEEE 754 as required by
+// the spec, then only the remainder needs special care.
+// http://es5.github.io/#x11.5
+// http://es5.github.io/#x11.6.3
+func arith(op token.Type, lnum, rnum types.Number) types.Number {
+	switch op {
+	case token.Minus:
+		return lnum - rnum
+	case token.Mul:
+		return lnum * rnum
+	case token.Quo:
+		return lnum / rnum
+	case token.Rem:
+		return types.Number(math.Mod(float64(lnum), float64(rnum)))
+	}
+
+	panic(fmt.Sprintf("unexpected arithmetic operator: %s", op))
+}
+
+// bitwise applies the shift and bitwise operators. Operands are
+// converted to 32 bits integers and only the 5 least significant
+// bits of the shift count are used.
+// http://es5.github.io/#x11.7
+// http://es5.github.io/#x11.10
+func bitwise(op token.Type, lnum, rnum types.Number) types.Number {
+	lint := lnum.ToInt32()
+
+	switch op {
+	case token.And:
+		return types.Number(lint & rnum.ToInt32())
+	case token.Or:
+		return types.Number(lint | rnum.ToInt32())
+	case token.Xor:
+		return types.Number(lint ^ rnum.ToInt32())
+	}
+
+	shift := rnum.ToUint32() & 0x1F
+
+	switch op {
+	case token.LShift:
+		return types.Number(lint << shift)
+	case token.RShift:
+		return types.Number(lint >> shift)
+	case token.RShiftZero:
+		return types.Number(lnum.ToUint32() >> shift)
+	}
+
+	panic(fmt.Sprintf("unexpected bitwise operator: %s", op))
+}
+
+// compare implements the relational operators on top of the
+// abstract relational comparison algorithm.
+// http://es5.github.io/#x11.8
+func compare(op token.Type, lval, rval types.Value) (types.Value, error) {
+	var (
+		res types.Value
+		err error
+	)
+
+	// WHY: undefined (NaN involved) is always false and
+	// a <= b is the same as !(b < a).
+	switch op {
+	case token.Less:
+		res, err = lessThan(lval, rval, true)
+		return types.Bool(res.IsTrue()), err
+	case token.Greater:
+		res, err = lessThan(rval, lval, false)
+		return types.Bool(res.IsTrue()), err
+	case token.LessEq:
+		res, err = lessThan(rval, lval, false)
+	case token.GreaterEq:
+		res, err = lessThan(lval, rval, true)
+	default:
+		panic(fmt.Sprintf("unexpected relational operator: %s", op))
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
-	if objval.Kind() != types.KindObject {
-		panic("wrapping primitive values not implemented yet")
+	return types.Bool(res.Kind() != types.KindUndefined && res.IsFalse()), nil
+}
+
+// lessThan is the abstract relational comparison algorithm (x < y).
+// The leftFirst flag controls the order of the ToPrimitive
+// conversions, that could have side effects. It returns undefined
+// when at least one of the operands is NaN.
+// http://es5.github.io/#x11.8.5
+func lessThan(x, y types.Value, leftFirst bool) (types.Value, error) {
+	var (
+		px, py types.Value
+		err    error
+	)
+
+	if leftFirst {
+		px, err = x.ToPrimitive(types.KindNumber)
+		if err == nil {
+			py, err = y.ToPrimitive(types.KindNumber)
+		}
+	} else {
+		py, err = y.ToPrimitive(types.KindNumber)
+		if err == nil {
+			px, err = x.ToPrimitive(types.KindNumber)
+		}
 	}
 
-	obj, err := objval.ToObject()
+	if err != nil {
+		return types.Bool(false), err
+	}
+
+	if px.Kind() == types.KindString && py.Kind() == types.KindString {
+		return types.Bool(px.(types.String).Less(py.(types.String))), nil
+	}
+
+	nx := float64(px.ToNumber())
+	ny := float64(py.ToNumber())
+
+	if math.IsNaN(nx) || math.IsNaN(ny) {
+		return types.Undefined, nil
+	}
+
+	return types.Bool(nx < ny), nil
+}
+
+// equal is the abstract equality comparison algorithm (x == y).
+// http://es5.github.io/#x11.9.3
+func equal(x, y types.Value) (bool, error) {
+	xkind := x.Kind()
+	ykind := y.Kind()
+
+	if xkind == ykind {
+		return types.StrictEqual(x, y), nil
+	}
+
+	isNil := func(k types.Kind) bool {
+		return k == types.KindUndefined || k == types.KindNull
+	}
+
+	switch {
+	case isNil(xkind) && isNil(ykind):
+		return true, nil
+	case xkind == types.KindNumber && ykind == types.KindString:
+		return equal(x, y.ToNumber())
+	case xkind == types.KindString && ykind == types.KindNumber:
+		return equal(x.ToNumber(), y)
+	case xkind == types.KindBool:
+		return equal(x.ToNumber(), y)
+	case ykind == types.KindBool:
+		return equal(x, y.ToNumber())
+	case (xkind == types.KindNumber || xkind == types.KindString) &&
+		ykind == types.KindObject:
+		py, err := y.ToPrimitive(types.KindNumber)
+		if err != nil {
+			return false, err
+		}
+		return equal(x, py)
+	case xkind == types.KindObject &&
+		(ykind == types.KindNumber || ykind == types.KindString):
+		px, err := x.ToPrimitive(types.KindNumber)
+		if err != nil {
+			return false, err
+		}
+		return equal(px, y)
+	}
+
+	return false, nil
+}
+
+// compoundOperators maps the compound assignment operators
+// to the binary operator they apply.
+var compoundOperators = map[token.Type]token.Type{
+	token.AddAssign:        token.Plus,
+	token.SubAssign:        token.Minus,
+	token.MulAssign:        token.Mul,
+	token.QuoAssign:        token.Quo,
+	token.RemAssign:        token.Rem,
+	token.LShiftAssign:     token.LShift,
+	token.RShiftAssign:     token.RShift,
+	token.RShiftZeroAssign: token.RShiftZero,
+	token.AndAssign:        token.And,
+	token.OrAssign:         token.Or,
+	token.XorAssign:        token.Xor,
+}
+
+// evalAssignExpr evaluates the simple (=) and compound (+=, -=, etc)
+// assignments. The assigned value is the result of the expression.
+// http://es5.github.io/#x11.13
+func (a *Abad) evalAssignExpr(expr *ast.AssignExpr) (types.Value, error) {
+	ref, err := a.evalRef(expr.Target, errInvalidAssignTarget)
 	if err != nil {
 		return nil, err
 	}
 
-	return obj.Get(utf16.Str(member.Property))
+	if expr.Operator == token.Assign {
+		val, err := a.evalExpr(expr.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return val, a.putValue(ref, val)
+	}
+
+	op, ok := compoundOperators[expr.Operator]
+	if !ok {
+		return nil, fmt.Errorf("unsupported assignment operator: %s",
+			expr.Operator)
+	}
+
+	lval, err := a.getValue(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	rval, err := a.evalExpr(expr.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := binaryOp(op, lval, rval)
+	if err != nil {
+		return nil, err
+	}
+
+	return val, a.putValue(ref, val)
 }
 
+// evalUpdateExpr evaluates the prefix and postfix increment and
+// decrement operators. The prefix form results in the new value and
+// the postfix form in the old one, converted to number.
+// http://es5.github.io/#x11.3
+// http://es5.github.io/#x11.4.4
+// http://es5.github.io/#x11.4.5
+func (a *Abad) evalUpdateExpr(expr *ast.UpdateExpr) (types.Value, error) {
+	invalidTarget := errInvalidPostfixTarget
+	if expr.Prefix {
+		invalidTarget = errInvalidPrefixTarget
+	}
+
+	ref, err := a.evalRef(expr.Operand, invalidTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := a.getValue(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	oldValue, err := types.ToNumber(val)
+	if err != nil {
+		return nil, err
+	}
+
+	newValue := oldValue + 1
+	if expr.Operator == token.Dec {
+		newValue = oldValue - 1
+	}
+
+	err = a.putValue(ref, newValue)
+	if err != nil {
+		return nil, err
+	}
+
+	if expr.Prefix {
+		return newValue, nil
+	}
+
+	return oldValue, nil
+}
+
+// evalRef evaluates n as a reference. Only identifiers and
+// property accessors are references, other expressions are
+// evaluated (for its side effects) and then rejected with a
+// ReferenceError with the message invalidTarget.
+func (a *Abad) evalRef(n ast.Node, invalidTarget string) (*reference, error) {
+	switch n.Type() {
+	case ast.NodeIdent:
+		return a.identRef(n.(ast.Ident)), nil
+	case ast.NodeMemberExpr:
+		return a.memberRef(n.(*ast.MemberExpr))
+	}
+
+	if _, err := a.evalExpr(n); err != nil {
+		return nil, err
+	}
+
+	return nil, types.NewReferenceError("%s", invalidTarget)
+}
+
+// http://es5.github.io/#x10.3.1
+func (a *Abad) identRef(ident ast.Ident) *reference {
+	name := utf16.Str(ident)
+	return &reference{
+		env:  a.ctx.lexEnv.GetIdentifierReference(name),
+		name: name,
+	}
+}
+
+// http://es5.github.io/#x11.2.1
+func (a *Abad) memberRef(member *ast.MemberExpr) (*reference, error) {
+	base, err := a.evalExpr(member.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := a.propertyName(member)
+	if err != nil {
+		return nil, err
+	}
+
+	// CheckObjectCoercible
+	if base.Kind() == types.KindUndefined || base.Kind() == types.KindNull {
+		return nil, types.NewTypeError("Cannot read property '%s' of %s",
+			name, base.ToString())
+	}
+
+	return &reference{base: base, name: name}, nil
+}
+
+// propertyName returns the name of the property accessed by member,
+// computed names are converted with ToString.
+func (a *Abad) propertyName(member *ast.MemberExpr) (utf16.Str, error) {
+	if !member.Computed {
+		return utf16.Str(member.Property.(ast.Ident)), nil
+	}
+
+	key, err := a.evalExpr(member.Property)
+	if err != nil {
+		return nil, err
+	}
+
+	prim, err := key.ToPrimitive(types.KindString)
+	if err != nil {
+		return nil, err
+	}
+
+	return utf16.Str(prim.ToString()), nil
+}
+
+// getValue reads the value pointed by ref.
+// http://es5.github.io/#x8.7.1
+func (a *Abad) getValue(ref *reference) (types.Value, error) {
+	if ref.env != nil {
+		return ref.env.Get(ref.name, true)
+	}
+
+	if ref.base == nil {
+		return nil, types.NewReferenceError("%s is not defined", ref.name)
+	}
+
+	// primitive values are wrapped in a new object
+	obj, err := types.ToObject(ref.base, a.objectProto)
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.Get(ref.name)
+}
+
+// putValue writes val in the location pointed by ref. Unresolvable
+// names are created in the global object, except in strict mode code
+// where, as the writes that fail, they throw an error.
+// http://es5.github.io/#x8.7.2
+func (a *Abad) putValue(ref *reference, val types.Value) error {
+	strict := a.ctx.strict
+
+	if ref.env != nil {
+		return ref.env.Set(ref.name, val, strict)
+	}
+
+	if ref.base == nil {
+		if strict {
+			return types.NewReferenceError("%s is not defined", ref.name)
+		}
+
+		return a.global.Put(ref.name, val, false)
+	}
+
+	if ref.base.Kind() != types.KindObject {
+		// properties of primitive values are not observable.
+		if strict {
+			return types.NewTypeError("Cannot create property '%s' on %s",
+				ref.name, ref.base.ToString())
+		}
+
+		return nil
+	}
+
+	obj, err := ref.base.ToObject()
+	if err != nil {
+		return err
+	}
+
+	return obj.Put(ref.name, val, strict)
+}
+
+func (a *Abad) evalIdentExpr(ident ast.Ident) (types.Value, error) {
+	return a.getValue(a.identRef(ident))
+}
+
+func (a *Abad) evalMemberExpr(member *ast.MemberExpr) (types.Value, error) {
+	ref, err := a.memberRef(member)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.getValue(ref)
+}
+
+// http://es5.github.io/#x11.2.3
 func (a *Abad) evalCallExpr(call *ast.CallExpr) (types.Value, error) {
-	// TODO(i4k): safe to assume the AST is ok?
-	objval, err := a.evalExpr(call.Callee)
+	objval, this, err := a.evalCallee(call.Callee)
 	if err != nil {
 		return nil, err
 	}
 
-	obj, err := objval.ToObject() // wraps primitives (if needed)
+	args, err := a.evalArgs(call.Args)
 	if err != nil {
 		return nil, err
 	}
 
-	fun, ok := obj.(types.Function)
+	fun, ok := objval.(types.Function)
 	if !ok {
-		return nil, fmt.Errorf("%s is not a function", objval.Kind())
+		return nil, types.NewTypeError("%s is not a function", call.Callee)
 	}
 
-	args, err := a.evalArgs(call.Args)
+	return fun.Call(this, args)
+}
+
+// evalCallee evaluates the callee of a call and the this value
+// of the call: the base object of property references or, for
+// other callees, undefined (nil).
+func (a *Abad) evalCallee(callee ast.Node) (types.Value, types.Object, error) {
+	var (
+		ref *reference
+		err error
+	)
+
+	switch callee.Type() {
+	case ast.NodeIdent:
+		ref = a.identRef(callee.(ast.Ident))
+	case ast.NodeMemberExpr:
+		ref, err = a.memberRef(callee.(*ast.MemberExpr))
+		if err != nil {
+			return nil, nil, err
+		}
+	default:
+		val, err := a.evalExpr(callee)
+		return val, nil, err
+	}
+
+	val, err := a.getValue(ref)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ref.env != nil {
+		this, _ := ref.env.ImplicitThis().(types.Object)
+		return val, this, nil
+	}
+
+	// the this value of methods of primitive values is the object
+	// wrapping them, as non-strict functions get it.
+	this, err := types.ToObject(ref.base, a.objectProto)
+	return val, this, err
+}
+
+// http://es5.github.io/#x11.2.2
+func (a *Abad) evalNewExpr(expr *ast.NewExpr) (types.Value, error) {
+	callee, err := a.evalExpr(expr.Callee)
 	if err != nil {
 		return nil, err
 	}
 
-	return fun.Call(obj, args), nil
+	args, err := a.evalArgs(expr.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	ctor, ok := callee.(types.Constructor)
+	if !ok {
+		return nil, types.NewTypeError("%s is not a constructor", expr.Callee)
+	}
+
+	return ctor.Construct(args)
 }
 
 func (a *Abad) evalArgs(args []ast.Node) ([]types.Value, error) {
@@ -2,6 +2,7 @@ package abad_test
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/NeowayLabs/abad"
@@ -86,7 +87,7 @@ func TestIdentExprEval(t *testing.T) {
 		},
 		{
 			code: "angular",
-			err:  E("[angular] is not defined"),
+			err:  types.NewReferenceError("angular is not defined"),
 		},
 	} {
 		js, err := abad.NewAbad()
@@ -137,3 +138,1697 @@ func TestStringEval(t *testing.T) {
 		})
 	}
 }
+
+func TestArithmeticEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: "1 + 2", want: types.Number(3)},
+		{code: "1 + 2 * 3", want: types.Number(7)},
+		{code: "(1 + 2) * 3", want: types.Number(9)},
+		{code: "10 - 2 - 3", want: types.Number(5)},
+		{code: "7 % 3", want: types.Number(1)},
+		{code: "-7 % 3", want: types.Number(-1)},
+		{code: "5.5 % 2", want: types.Number(1.5)},
+		{code: "1 / 2", want: types.Number(0.5)},
+		{code: "1 / 0", want: types.Number(math.Inf(1))},
+		{code: "-1 / 0", want: types.Number(math.Inf(-1))},
+		{code: "1 / -0", want: types.Number(math.Inf(-1))},
+		{code: "1 / (-0 + -0)", want: types.Number(math.Inf(-1))},
+		{code: "1 / (0 - 0)", want: types.Number(math.Inf(1))},
+		{code: "0 / 0", want: types.Number(math.NaN())},
+		{code: "5 % 0", want: types.Number(math.NaN())},
+		{code: "1 / 0 - 1 / 0", want: types.Number(math.NaN())},
+		{code: `"6" * "7"`, want: types.Number(42)},
+		{code: `"0x10" - 1`, want: types.Number(15)},
+		{code: `" 12 " / 4`, want: types.Number(3)},
+		{code: `"" * 1`, want: types.Number(0)},
+		{code: `"a" * 1`, want: types.Number(math.NaN())},
+		{code: `"1e1000" * 1`, want: types.Number(math.Inf(1))},
+		{code: "true + true", want: types.Number(2)},
+		{code: "null + 1", want: types.Number(1)},
+		{code: "undefined + 1", want: types.Number(math.NaN())},
+		{code: `"a" + "b"`, want: types.NewString("ab")},
+		{code: `"a" + 1 + 2`, want: types.NewString("a12")},
+		{code: `1 + 2 + "a"`, want: types.NewString("3a")},
+		{code: `"" + 1 / 3`, want: types.NewString("0.3333333333333333")},
+		{code: `"" + 1e21`, want: types.NewString("1e+21")},
+		{code: `"" + 1e-7`, want: types.NewString("1e-7")},
+		{code: `"" + 123e-20`, want: types.NewString("1.23e-18")},
+		{code: `"" + 0.000001`, want: types.NewString("0.000001")},
+		{code: `"" + -0`, want: types.NewString("0")},
+		{code: `"" + 1 / 0`, want: types.NewString("Infinity")},
+		{code: `"" + null + undefined + true`, want: types.NewString("nullundefinedtrue")},
+		{code: `"x" + console`, want: types.NewString("x[object Object]")},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func assertEqualValues(t *testing.T, want, got types.Value, code string) {
+	t.Helper()
+
+	if want.Kind() != got.Kind() {
+		t.Fatalf("%s: want kind[%s] but got kind[%s]", code,
+			want.Kind(), got.Kind())
+	}
+
+	// WHY: comparing the string representation also works
+	// for NaN (NaN !== NaN).
+	wantstr := want.ToString().String()
+	gotstr := got.ToString().String()
+	if wantstr != gotstr {
+		t.Fatalf("%s: want[%s] but got[%s]", code, wantstr, gotstr)
+	}
+}
+
+func TestArithmeticEvalErrors(t *testing.T) {
+	thrown := types.NewThrownValue(types.NewString("v"))
+	noPrimitive := types.NewTypeError("DataObject has no defaultValue")
+
+	for _, tc := range []struct {
+		code string
+		want error
+	}{
+		{code: `var o = {valueOf: function () { throw "v" }}; o * 2`, want: thrown},
+		{code: `var o = {valueOf: function () { throw "v" }}; 2 - o`, want: thrown},
+		{code: `var o = {valueOf: function () { throw "v" }}; o / 2`, want: thrown},
+		{code: `var o = {valueOf: function () { throw "v" }}; o % 2`, want: thrown},
+		{code: `var o = {valueOf: function () { throw "v" }}; -o`, want: thrown},
+		{code: `var o = {valueOf: function () { throw "v" }}; +o`, want: thrown},
+		{code: `var o = {valueOf: function () { throw "v" }}; ~o`, want: thrown},
+		{code: `var o = {valueOf: function () { throw "v" }}; o | 0`, want: thrown},
+		{code: `var o = {valueOf: function () { throw "v" }}; 1 & o`, want: thrown},
+		{code: `var o = {valueOf: function () { throw "v" }}; o ^ 1`, want: thrown},
+		{code: `var o = {valueOf: function () { throw "v" }}; o << 1`, want: thrown},
+		{code: `var o = {valueOf: function () { throw "v" }}; 1 >> o`, want: thrown},
+		{code: `var o = {valueOf: function () { throw "v" }}; o >>> 1`, want: thrown},
+		{code: `var o = {valueOf: function () { throw "v" }}; var a = 1; a |= o`, want: thrown},
+		{
+			code: `var o = {valueOf: function () { throw "v" }}; o * (function () { throw "r" })()`,
+			want: types.NewThrownValue(types.NewString("r")),
+		},
+		{
+			code: `var o = {valueOf: function () { return {} }, toString: function () { return {} }}; o * 2`,
+			want: noPrimitive,
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		_, err = js.Eval(tc.code)
+		assert.EqualErrs(t, tc.want, err, "error mismatch for %s", tc.code)
+	}
+}
+
+func TestComparisonEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want bool
+	}{
+		{code: "1 < 2", want: true},
+		{code: "2 < 1", want: false},
+		{code: "1 <= 1", want: true},
+		{code: "1 >= 2", want: false},
+		{code: "2 > 1", want: true},
+		{code: "0 / 0 < 1", want: false},
+		{code: "0 / 0 >= 1", want: false},
+		{code: "0 / 0 <= 0 / 0", want: false},
+		{code: "-0 < 0", want: false},
+		{code: "-0 <= 0", want: true},
+		{code: "-1 / 0 < 1 / 0", want: true},
+		{code: `"a" < "b"`, want: true},
+		{code: `"a" < "B"`, want: false},
+		{code: `"abc" < "abcd"`, want: true},
+		{code: `"10" < "9"`, want: true},
+		{code: `10 < "9"`, want: false},
+		{code: `"10" < 9`, want: false},
+		{code: `"a" < 1`, want: false},
+		{code: `"a" >= 1`, want: false},
+		{code: "undefined < 1", want: false},
+		{code: "null < 1", want: true},
+		{code: "null >= 0", want: true},
+		{code: "null > 0", want: false},
+		{code: "true > false", want: true},
+		{code: "1 == 1", want: true},
+		{code: "0 == -0", want: true},
+		{code: "0 / 0 == 0 / 0", want: false},
+		{code: "0 / 0 != 0 / 0", want: true},
+		{code: "null == undefined", want: true},
+		{code: "null == 0", want: false},
+		{code: "undefined == 0", want: false},
+		{code: "null == false", want: false},
+		{code: `"" == 0`, want: true},
+		{code: `"0" == false`, want: true},
+		{code: `"1" == true`, want: true},
+		{code: `"2" == true`, want: false},
+		{code: `"  1 " == 1`, want: true},
+		{code: `"0x10" == 16`, want: true},
+		{code: `"a" == "a"`, want: true},
+		{code: `"a" != "b"`, want: true},
+		{code: `console == console`, want: true},
+		{code: `console.log == console.log`, want: true},
+		{code: `console == console.log`, want: false},
+		{code: `console == "[object Object]"`, want: true},
+		{code: `"1" === 1`, want: false},
+		{code: "null === undefined", want: false},
+		{code: "0 === -0", want: true},
+		{code: "0 / 0 === 0 / 0", want: false},
+		{code: "0.1 + 0.2 === 0.3", want: false},
+		{code: `"a" + "b" === "ab"`, want: true},
+		{code: "console.log === console.log", want: true},
+		{code: "console !== console.log", want: true},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, types.Bool(tc.want), got, tc.code)
+	}
+}
+
+func TestLogicalEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: "true && false", want: types.False},
+		{code: "1 && 2", want: types.Number(2)},
+		{code: `0 && "a"`, want: types.Number(0)},
+		{code: `"" || "a"`, want: types.NewString("a")},
+		{code: `"a" || "b"`, want: types.NewString("a")},
+		{code: "null || undefined", want: types.Undefined},
+		{code: "0 / 0 || null", want: types.Null},
+		{code: "1 && 2 || 3", want: types.Number(2)},
+		{code: "0 || 1 && 2", want: types.Number(2)},
+		{code: "!0", want: types.True},
+		{code: `!""`, want: types.True},
+		{code: `!"0"`, want: types.False},
+		{code: "!!console", want: types.True},
+		{code: "!null", want: types.True},
+		{code: "!(1 < 2)", want: types.False},
+
+		// right operand must not be evaluated
+		{code: "false && notdefined", want: types.False},
+		{code: "1 || notdefined", want: types.Number(1)},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestBitwiseEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want float64
+	}{
+		{code: "5 & 3", want: 1},
+		{code: "5 | 3", want: 7},
+		{code: "5 ^ 3", want: 6},
+		{code: "~5", want: -6},
+		{code: "~-1", want: 0},
+		{code: "~~3.7", want: 3},
+		{code: "~~-3.7", want: -3},
+		{code: "1 << 3", want: 8},
+		{code: "1 << 31", want: -2147483648},
+		{code: "1 << 32", want: 1},
+		{code: "1 << 33", want: 2},
+		{code: "-16 >> 2", want: -4},
+		{code: "-16 >>> 2", want: 1073741820},
+		{code: "-1 >>> 0", want: 4294967295},
+		{code: "-1 >>> 32", want: 4294967295},
+		{code: "4294967296 | 0", want: 0},
+		{code: "2147483648 | 0", want: -2147483648},
+		{code: "1e21 | 0", want: -559939584},
+		{code: "0 / 0 | 0", want: 0},
+		{code: "1 / 0 | 0", want: 0},
+		{code: `"12" | 0`, want: 12},
+		{code: `"0xff" >>> 0`, want: 255},
+		{code: "3.9 >> 0", want: 3},
+		{code: "-3.9 >>> 0", want: 4294967293},
+		{code: "1 << -1", want: -2147483648},
+		{code: "1 + 2 << 1", want: 6},
+		{code: "6 & 3 == 3", want: 0},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, types.Number(tc.want), got, tc.code)
+	}
+}
+
+func TestAssignEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: "a = 1", want: types.Number(1)},
+		{code: "a = 1; a", want: types.Number(1)},
+		{code: "a = b = 3; a + b", want: types.Number(6)},
+		{code: "a = 1; a = a + 1; a", want: types.Number(2)},
+		{code: "a = 1; a += 2", want: types.Number(3)},
+		{code: `a = "abad"; a += 1; a`, want: types.NewString("abad1")},
+		{code: "a = 10; a -= 4; a *= 3; a /= 2; a %= 5", want: types.Number(4)},
+		{code: "a = 1; a <<= 4; a >>= 1; a", want: types.Number(8)},
+		{code: "a = -1; a >>>= 28", want: types.Number(15)},
+		{code: "a = 5; a &= 3; a |= 8; a ^= 1", want: types.Number(8)},
+		{code: "a = undefined; a", want: types.Undefined},
+		{code: "console.x = 1; console.x += 1; console.x", want: types.Number(2)},
+		{code: "console.x = console.y = true; console.y", want: types.Bool(true)},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestAssignEvalErrors(t *testing.T) {
+	invalidTarget := E("parser error: <interactive>:1:0: Invalid left-hand side in assignment")
+
+	for _, tc := range []struct {
+		code string
+		want error
+	}{
+		{
+			code: "1 = 2",
+			want: invalidTarget,
+		},
+		{
+			code: "a + 1 = 2",
+			want: invalidTarget,
+		},
+		{
+			code: "a = 1; a + 1 = 2",
+			want: invalidTarget,
+		},
+		{
+			code: "console.log() += 2",
+			want: types.NewReferenceError("Invalid left-hand side in assignment"),
+		},
+		{
+			code: "a += 1",
+			want: types.NewReferenceError("a is not defined"),
+		},
+		{
+			code: "a.b = 1",
+			want: types.NewReferenceError("a is not defined"),
+		},
+		{
+			code: "a = null; a.b = 1",
+			want: types.NewTypeError("Cannot read property 'b' of null"),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		_, err = js.Eval(tc.code)
+		assert.EqualErrs(t, tc.want, err, "error mismatch for %s", tc.code)
+	}
+}
+
+func TestVarDeclEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+		err  error
+	}{
+		{code: "var a = 1; a", want: types.Number(1)},
+		{code: "var a = 1, b = a + 1; b", want: types.Number(2)},
+		{code: "var a; a", want: types.Undefined},
+		{code: "var a = 1; var a; a", want: types.Number(1)},
+		{code: "var a = 1; var a = 2; a", want: types.Number(2)},
+		{code: "var b = a; var a = 1; b", want: types.Undefined},
+		{code: "a = 1; var a; a", want: types.Number(1)},
+		{code: "var a = b = 2; a + b", want: types.Number(4)},
+		{code: "var a = 1; a += 1; a", want: types.Number(2)},
+		{code: `1; var a = "a"`, want: types.Number(1)},
+		{code: "var console = 1; console", want: types.Number(1)},
+		{
+			code: "var a = b",
+			err:  types.NewReferenceError("b is not defined"),
+		},
+		{
+			code: "var a = 1; a + b",
+			err:  types.NewReferenceError("b is not defined"),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.EqualErrs(t, tc.err, err, "error mismatch for %s", tc.code)
+
+		if err != nil {
+			continue
+		}
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestFunctionEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+		err  error
+	}{
+		{
+			code: "function f(a, b) { return a + b }; f(1, 2)",
+			want: types.Number(3),
+		},
+		{
+			code: "function f(a, b) { return b }; f(1)",
+			want: types.Undefined,
+		},
+		{
+			code: "function f(a) { return a }; f(1, 2, 3)",
+			want: types.Number(1),
+		},
+		{
+			code: "function f() { var a = 1 }; f()",
+			want: types.Undefined,
+		},
+		{
+			code: "function f() { return; 1 }; f()",
+			want: types.Undefined,
+		},
+		{
+			code: "var a = f(); function f() { return 42 }; a",
+			want: types.Number(42),
+		},
+		{
+			code: `
+				function fib(n) {
+					return n < 2 && n || n > 1 && fib(n - 1) + fib(n - 2) || 0
+				}
+				fib(15)
+			`,
+			want: types.Number(610),
+		},
+		{
+			code: "function f() { return f() }; f()",
+			err:  types.NewRangeError("Maximum call stack size exceeded"),
+		},
+		{
+			code: `
+				function f() { return f() }
+				var a = "not caught"
+				try { f() } catch (e) { a = e.toString() }
+				a
+			`,
+			want: types.NewString("RangeError: Maximum call stack size exceeded"),
+		},
+		{
+			code: `
+				var a = "global"
+				function f(a) { a = "param"; return a }
+				f("arg") + a
+			`,
+			want: types.NewString("paramglobal"),
+		},
+		{
+			code: "function f() { var a = 1 }; f(); a",
+			err:  types.NewReferenceError("a is not defined"),
+		},
+		{
+			code: "function f() { b = 1 }; f(); b",
+			want: types.Number(1),
+		},
+		{
+			code: `
+				function counter() {
+					var count = 0
+					function inc() { count += 1; return count }
+					return inc
+				}
+				var a = counter(), b = counter()
+				a(); a()
+				a() * 10 + b()
+			`,
+			want: types.Number(31),
+		},
+		{
+			code: `
+				var x = 1
+				function get() { return x }
+				function f() { var x = 2; return get() }
+				f()
+			`,
+			want: types.Number(1),
+		},
+		{
+			code: "function f(a, b, c) {}; f.length",
+			want: types.Number(3),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.EqualErrs(t, tc.err, err, "error mismatch for %s", tc.code)
+
+		if err != nil {
+			continue
+		}
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestFunExprEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+		err  error
+	}{
+		{
+			code: "var f = function(a) { return a * 2 }; f(21)",
+			want: types.Number(42),
+		},
+		{
+			code: "(function() { return 1 })()",
+			want: types.Number(1),
+		},
+		{
+			code: "(function(a, b) { return a - b }(3, 1))",
+			want: types.Number(2),
+		},
+		{
+			code: "function apply(f, v) { return f(v) }; apply(function(x) { return x + 1 }, 1)",
+			want: types.Number(2),
+		},
+		{
+			code: "var f = function fact(n) { return n < 2 && 1 || n * fact(n - 1) }; f(6)",
+			want: types.Number(720),
+		},
+		{
+			code: "var f = function g() { g = 1; return g }; f() === f",
+			want: types.Bool(true),
+		},
+		{
+			code: "var f = function g() {}; g",
+			err:  types.NewReferenceError("g is not defined"),
+		},
+		{
+			code: `
+				function adder(x) { return function(y) { return x + y } }
+				var add2 = adder(2)
+				add2(3)
+			`,
+			want: types.Number(5),
+		},
+		{
+			code: `
+				var a = 1
+				var f = (function() { var a = 2; return function() { return a } })()
+				f() + a
+			`,
+			want: types.Number(3),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.EqualErrs(t, tc.err, err, "error mismatch for %s", tc.code)
+
+		if err != nil {
+			continue
+		}
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestFunctionPrototypeEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+		err  error
+	}{
+		{code: `function f() {} f.valueOf() === f`, want: types.True},
+		{code: `typeof (function () {}).toString`, want: types.NewString("function")},
+		{code: `var f = function () {}; f.toString === Array.isArray.toString`, want: types.True},
+		{code: `var f = function () {}; f.toString === [].join.toString`, want: types.True},
+		{
+			code: `(function (a) { return a }) + 1`,
+			want: types.NewString("function (a) {\nreturn a\n}1"),
+		},
+		{code: `Array + ""`, want: types.NewString("function () { [native code] }")},
+		{
+			code: `var s = Array.toString; s()`,
+			err: types.NewTypeError("Function.prototype.toString requires " +
+				"that 'this' be a Function"),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.EqualErrs(t, tc.err, err, "error mismatch for %s", tc.code)
+
+		if err != nil {
+			continue
+		}
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestIfBlockReturnEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: "if (true) 1; else 2", want: types.Number(1)},
+		{code: "if (0) 1; else 2", want: types.Number(2)},
+		{code: "3; if (false) 1", want: types.Number(3)},
+		{code: "3; {}", want: types.Number(3)},
+		{code: "{ 1; { 2 } }", want: types.Number(2)},
+		{code: "if (false) { var a = 1 }; a", want: types.Undefined},
+		{code: `if ("") { 1 } else if (null) { 2 } else { 3 }`, want: types.Number(3)},
+		{
+			code: `
+				function abs(n) {
+					if (n < 0) {
+						return -n
+					}
+					return n
+				}
+				abs(-2) + abs(3)
+			`,
+			want: types.Number(5),
+		},
+		{
+			code: `
+				function f() {
+					{ { if (true) { return "inner" } } }
+					return "outer"
+				}
+				f()
+			`,
+			want: types.NewString("inner"),
+		},
+		{
+			code: `
+				function fib(n) {
+					if (n < 2) return n
+					return fib(n - 1) + fib(n - 2)
+				}
+				fib(20)
+			`,
+			want: types.Number(6765),
+		},
+		{
+			code: "function f() { if (true) return }; f()",
+			want: types.Undefined,
+		},
+		{
+			code: "{ function f() { return 1 } }; f()",
+			want: types.Number(1),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestLoopEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: "var i = 0; while (i < 3) { i += 1 }", want: types.Number(3)},
+		{code: "var i = 0; while (i < 3) i += 1; i", want: types.Number(3)},
+		{code: "var i = 5; while (i < 3) i += 1; i", want: types.Number(5)},
+		{code: "var i = 5; do i += 1; while (i < 3); i", want: types.Number(6)},
+		{code: "do { 1; break } while (true)", want: types.Number(1)},
+		{code: "for (;;) { 5; break }", want: types.Number(5)},
+		{
+			code: "var s = 0; for (var i = 0; i < 5; i += 1) { s += i }; s",
+			want: types.Number(10),
+		},
+		{
+			code: "for (var i = 0; i < 5; i += 1) {}; i",
+			want: types.Number(5),
+		},
+		{
+			code: "var s = 0; for (var i = 0; i < 10; i += 1) { if (i % 3) continue; s += i }; s",
+			want: types.Number(18),
+		},
+		{
+			code: "var i = 0; while (true) { i += 1; if (i == 7) break }; i",
+			want: types.Number(7),
+		},
+		{
+			code: "var n = 0; do { n += 1; if (n < 3) continue; break } while (true); n",
+			want: types.Number(3),
+		},
+		{
+			code: `
+				var n = 0
+				for (var i = 0; i < 3; i += 1) {
+					for (var j = 0; j < 3; j += 1) {
+						if (j == i) break
+						n += 1
+					}
+				}
+				n
+			`,
+			want: types.Number(3),
+		},
+		{
+			code: `
+				function find(v) {
+					var i = 0
+					while (true) {
+						if (i == v) return "found"
+						i += 1
+					}
+				}
+				find(4)
+			`,
+			want: types.NewString("found"),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestForInEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{
+			code: `function o() {}; o.b = 1; o.a = 2; var s = ""; for (var k in o) s += k; s`,
+			want: types.NewString("ba"),
+		},
+		{
+			code: `var s = ""; for (var k in "abc") s += k; s`,
+			want: types.NewString("012"),
+		},
+		{
+			code: `var n = 0; for (var k in null) n += 1; for (k in undefined) n += 1; n`,
+			want: types.Number(0),
+		},
+		{
+			code: `for (var k in "ab") {}; k`,
+			want: types.NewString("1"),
+		},
+		{
+			code: `function o() {}; o.a = 1; o.b = 2; for (o.x in o) if (o.x == "b") break; o.x`,
+			want: types.NewString("b"),
+		},
+		{
+			code: `function o() {}; o.a = 1; o.b = 2; var n = 0; for (var k in o) { o.c = 3; n += 1 }; n`,
+			want: types.Number(2),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestLabeledStmtEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: `l: { 1; break l; 2 }`, want: types.Number(1)},
+		{code: `l: 5`, want: types.Number(5)},
+		{
+			code: `
+				var n = 0
+				outer: for (var i = 0; i < 3; i += 1) {
+					for (var j = 0; j < 3; j += 1) {
+						if (j == 1) continue outer
+						n += 1
+					}
+				}
+				n
+			`,
+			want: types.Number(3),
+		},
+		{
+			code: `
+				var n = 0
+				outer: while (true) {
+					while (true) {
+						n += 1
+						if (n == 5) break outer
+					}
+				}
+				n
+			`,
+			want: types.Number(5),
+		},
+		{
+			code: `
+				var n = 0
+				a: b: do {
+					n += 1
+					if (n < 3) continue a
+					break b
+				} while (true)
+				n
+			`,
+			want: types.Number(3),
+		},
+		{
+			code: `
+				var s = ""
+				l: {
+					for (var k in "abc") {
+						if (k == 1) break l
+						s += k
+					}
+					s += "never"
+				}
+				s
+			`,
+			want: types.NewString("0"),
+		},
+		{
+			code: `
+				function f() {
+					l: while (true) {
+						while (true) return 7
+					}
+				}
+				f()
+			`,
+			want: types.Number(7),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestSwitchEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: `"none"; switch (1) {}`, want: types.NewString("none")},
+		{code: `switch (1) { case 1: "one"; break; case 2: "two" }`, want: types.NewString("one")},
+		{code: `switch (2) { case 1: "one"; case 2: "two"; case 3: "three" }`, want: types.NewString("three")},
+		{code: `switch ("1") { case 1: "number"; break; default: "string" }`, want: types.NewString("string")},
+		{code: `switch (5) { case 1: "one"; default: "default"; case 2: "two"; break; case 3: "three" }`, want: types.NewString("two")},
+		{code: `switch (3) { case 1: "one"; default: "default"; case 3: "three" }`, want: types.NewString("three")},
+		{code: `"none"; switch (3) { case 1: 1; case 2: 2 }`, want: types.NewString("none")},
+		{code: `var n = 0; switch (n += 1) { case n: n += 10 }; n`, want: types.Number(11)},
+		{
+			code: `var s = ""; switch ("b") { case s += "a": s += "!"; case "b": s += "c"; default: s += "d" }; s`,
+			want: types.NewString("acd"),
+		},
+		{
+			code: `
+				var n = 0
+				for (var i = 0; i < 5; i += 1) {
+					switch (i % 2) {
+					case 0:
+						continue
+					}
+					n += 1
+				}
+				n
+			`,
+			want: types.Number(2),
+		},
+		{
+			code: `
+				function f(v) {
+					switch (v) {
+					case "a": return 1
+					default: return 0
+					}
+				}
+				f("a") + f("b")
+			`,
+			want: types.Number(1),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestTryEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: `try { throw 1 } catch (e) { e }`, want: types.Number(1)},
+		{code: `try { 1 } catch (e) { 2 }`, want: types.Number(1)},
+		{code: `try { throw "a" } catch (e) { e } finally { "f" }`, want: types.NewString("a")},
+		{code: `try { 1 } finally { 2 }`, want: types.Number(1)},
+		{code: `try { undefinedVar } catch (e) { e.message }`, want: types.NewString("undefinedVar is not defined")},
+		{code: `try { undefinedVar } catch (e) { e.name }`, want: types.NewString("ReferenceError")},
+		{code: `try { var a = 1; a() } catch (e) { "" + e }`, want: types.NewString("TypeError: a is not a function")},
+		{code: `try { console.x() } catch (e) { e.message }`, want: types.NewString("console.x is not a function")},
+		{code: `var s = ""; var f; try { f(s += "arg") } catch (e) {}; s`, want: types.NewString("arg")},
+		{code: `var e = 1; try { throw 2 } catch (e) { e = 3 }; e`, want: types.Number(1)},
+		{code: `try { throw 2 } catch (e) { var e = 3 }; e`, want: types.Undefined},
+		{code: `try { try { throw 1 } finally { 2 } } catch (e) { e }`, want: types.Number(1)},
+		{code: `try { try { throw 1 } catch (e) { throw e + 1 } } catch (e) { e }`, want: types.Number(2)},
+		{
+			code: `var s = ""; try { try { throw 1 } finally { s += "f" } } catch (e) { s += e }; s`,
+			want: types.NewString("f1"),
+		},
+		{
+			code: `function f() { try { return 1 } finally { return 2 } }; f()`,
+			want: types.Number(2),
+		},
+		{
+			code: `function f() { try { throw 1 } finally { return 2 } }; f()`,
+			want: types.Number(2),
+		},
+		{
+			code: `var n = 0; function f() { try { return 1 } finally { n = 5 } }; f() + n`,
+			want: types.Number(6),
+		},
+		{
+			code: `var i = 0; while (true) { try { break } finally { i = 7 } }; i`,
+			want: types.Number(7),
+		},
+		{
+			code: `var i = 0; while (i < 3) { try { throw i } catch (e) { i += 1; continue } }; i`,
+			want: types.Number(3),
+		},
+		{
+			code: `function f() { throw "inner" }; try { f() } catch (e) { e }`,
+			want: types.NewString("inner"),
+		},
+		{
+			code: `var f; try { throw 7 } catch (e) { f = function () { return e } }; f()`,
+			want: types.Number(7),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestThrowEvalErrors(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want error
+	}{
+		{code: `throw 1`, want: types.NewThrownValue(types.Number(1))},
+		{code: `throw "error"`, want: types.NewThrownValue(types.NewString("error"))},
+		{
+			code: `try { throw 1 } catch (e) { throw 2 }`,
+			want: types.NewThrownValue(types.Number(2)),
+		},
+		{
+			code: `try { throw 1 } finally { throw 2 }`,
+			want: types.NewThrownValue(types.Number(2)),
+		},
+		{
+			code: `try { throw 1 } finally {}`,
+			want: types.NewThrownValue(types.Number(1)),
+		},
+		{
+			code: `try {} catch (e) {}; e`,
+			want: types.NewReferenceError("e is not defined"),
+		},
+		{
+			code: `a()`,
+			want: types.NewReferenceError("a is not defined"),
+		},
+		{
+			code: `var a = 1; a()`,
+			want: types.NewTypeError("a is not a function"),
+		},
+		{
+			code: `var a = 1; a(b)`,
+			want: types.NewReferenceError("b is not defined"),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		_, err = js.Eval(tc.code)
+		assert.EqualErrs(t, tc.want, err, "error mismatch for %s", tc.code)
+	}
+}
+
+func TestConditionalSequenceEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: `true ? 1 : 2`, want: types.Number(1)},
+		{code: `0 ? 1 : 2`, want: types.Number(2)},
+		{code: `var a = 5; a > 3 ? "big" : a > 1 ? "medium" : "small"`, want: types.NewString("big")},
+		{code: `var a = 2; a > 3 ? "big" : a > 1 ? "medium" : "small"`, want: types.NewString("medium")},
+		{code: `var a = 0; a > 3 ? "big" : a > 1 ? "medium" : "small"`, want: types.NewString("small")},
+		{code: `var a = 1, b = 1; true ? a += 1 : b += 1; a + b`, want: types.Number(3)},
+		{code: `1, 2, 3`, want: types.Number(3)},
+		{code: `var a = (1, 2); a`, want: types.Number(2)},
+		{code: `var a, b; a = 1, b = 2; a + b`, want: types.Number(3)},
+		{
+			code: `var s = 0; for (var i = 0, j = 10; i < j; i += 1, j -= 1) s += 1; s`,
+			want: types.Number(5),
+		},
+		{
+			code: `function f(a, b) { return b }; f((1, 2), 3)`,
+			want: types.Number(3),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestObjectLiteralEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: `var o = {a: 1, b: "b"}; o.a`, want: types.Number(1)},
+		{code: `var o = {a: 1, b: "b"}; o.b`, want: types.NewString("b")},
+		{code: `var o = {}; o.a`, want: types.Undefined},
+		{code: `var o = {a: {b: {c: 3}}}; o.a.b.c`, want: types.Number(3)},
+		{code: `var o = {a: 1, a: 2}; o.a`, want: types.Number(2)},
+		{code: `var o = {if: 1, null: 2}; o.if + o.null`, want: types.Number(3)},
+		{code: `var o = {}; o.x = 5; o.x`, want: types.Number(5)},
+		{code: `"" + {}`, want: types.NewString("[object Object]")},
+		{code: `({a: 1}).a`, want: types.Number(1)},
+		{code: `var n = 0; var o = {a: n += 1, b: n += 1}; n`, want: types.Number(2)},
+		{code: `var o = {get a() { return 7 }}; o.a`, want: types.Number(7)},
+		{code: `var o = {get a() { return 7 }}; o.a = 1; o.a`, want: types.Number(7)},
+		{code: `var v; var o = {set a(x) { v = x }}; o.a = 3; v`, want: types.Number(3)},
+		{code: `var o = {set a(x) {}}; o.a`, want: types.Undefined},
+		{
+			code: `var v = 0; var o = {get a() { return v }, set a(x) { v = x * 2 }}; o.a = 4; o.a`,
+			want: types.Number(8),
+		},
+		{
+			code: `var s = ""; for (var k in {b: 1, 2: 1, a: 1, 1: 1}) s += k; s`,
+			want: types.NewString("12ba"),
+		},
+		{
+			code: `var o = {a: 1}; var p = o; p.a = 2; o.a`,
+			want: types.Number(2),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestArrayEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: `[].length`, want: types.Number(0)},
+		{code: `[1, 2, 3].length`, want: types.Number(3)},
+		{code: `[1, , 3].length`, want: types.Number(3)},
+		{code: `[1, 2,].length`, want: types.Number(2)},
+		{code: `[1, , ].length`, want: types.Number(2)},
+		{code: `[, ,].length`, want: types.Number(2)},
+		{code: `"" + [1, , 3]`, want: types.NewString("1,,3")},
+		{code: `"" + [1, [2, 3], null, undefined]`, want: types.NewString("1,2,3,,")},
+		{code: `var a = [1, 2, 3]; a.length = 1; "" + a`, want: types.NewString("1")},
+		{code: `var a = [1]; a.length = 3; "" + a`, want: types.NewString("1,,")},
+		{code: `var s = ""; for (var k in [5, , 7]) s += k; s`, want: types.NewString("02")},
+		{code: `var n = 0; var a = [n += 1, , n += 1]; n`, want: types.Number(2)},
+		{code: `Array.isArray([])`, want: types.True},
+		{code: `Array.isArray({length: 0})`, want: types.False},
+		{code: `Array.isArray()`, want: types.False},
+		{code: `Array.isArray(Array.prototype)`, want: types.True},
+		{code: `[].constructor === Array`, want: types.True},
+		{code: `var a = Array(3); a.length`, want: types.Number(3)},
+		{code: `"" + Array(1, 2)`, want: types.NewString("1,2")},
+		{
+			code: `var a = [1]; try { a.length = -1 } catch (e) { e.name }`,
+			want: types.NewString("RangeError"),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestMemberChainEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: `var o = {a: 1}; o["a"]`, want: types.Number(1)},
+		{code: `"abc".length`, want: types.Number(3)},
+		{code: `var s = "abc"; s[1]`, want: types.NewString("b")},
+		{code: `var s = "abc"; s[3]`, want: types.Undefined},
+		{code: `var s = "abc"; s["length"] + "".length`, want: types.Number(3)},
+		{code: `var x = 1; x.y = 2; x.y`, want: types.Undefined},
+		{code: `true.y`, want: types.Undefined},
+		{code: `var s = "abc"; s.length = 1; s.length`, want: types.Number(3)},
+		{code: `typeof (1).toString`, want: types.NewString("function")},
+		{code: `typeof true.valueOf`, want: types.NewString("function")},
+		{code: `"a".hasOwnProperty("length")`, want: types.True},
+		{code: `"ab".hasOwnProperty(1) && !"ab".hasOwnProperty(2)`, want: types.True},
+		{
+			code: `
+				var s = ""
+				for (var i = 0; i < 2000; i++) { s += "a" }
+				var n = 0
+				for (var j = 0; j < s.length; j++) { if (s[j] === "a") { n++ } }
+				n
+			`,
+			want: types.Number(2000),
+		},
+		{code: `var o = {"a-b": 2}; o["a-b"]`, want: types.Number(2)},
+		{code: `var o = {}; var k = "x"; o[k] = 3; o.x`, want: types.Number(3)},
+		{code: `var o = {1: "one"}; o[1] + o["1"] + o[0.5 * 2]`, want: types.NewString("oneoneone")},
+		{code: `var o = {undefined: 1}; o[undefined]`, want: types.Number(1)},
+		{code: `var o = {x: 1}; o[{toString: function () { return "x" }}]`, want: types.Number(1)},
+		{code: `var a = [10, 20, 30]; a[0] + a[2]`, want: types.Number(40)},
+		{code: `var a = [10, 20, 30]; a[3]`, want: types.Undefined},
+		{code: `var a = []; a[4] = 1; a.length`, want: types.Number(5)},
+		{code: `var a = [[1, 2], [3, 4]]; a[1][0]`, want: types.Number(3)},
+		{code: `var a = [1]; a[0] += 5; a[0]`, want: types.Number(6)},
+		{code: `var n = 0; var a = [1, 2]; a[n += 1]`, want: types.Number(2)},
+		{code: `var o = {a: {b: function () { return {c: 3} }}}; o.a.b().c`, want: types.Number(3)},
+		{code: `function f() { return function () { return 7 } } f()()`, want: types.Number(7)},
+		{
+			code: `var a = {b: [function (d) { return {e: d * 2} }]}; var c = 0; a.b[c](4).e`,
+			want: types.Number(8),
+		},
+		{code: `(function () { return [1, 2] })()[1]`, want: types.Number(2)},
+		{code: `[5, 6][1]`, want: types.Number(6)},
+		{
+			code: `var s = ""; var o = {a: 1}; o[(s += "o", "a")] = (s += "v", 2); s + o.a`,
+			want: types.NewString("ov2"),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestMemberChainEvalErrors(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want error
+	}{
+		{
+			code: `null[0]`,
+			want: types.NewTypeError("Cannot read property '0' of null"),
+		},
+		{
+			code: `var o = {}; o.a[1]`,
+			want: types.NewTypeError("Cannot read property '1' of undefined"),
+		},
+		{
+			code: `var o = {}; o.a()`,
+			want: types.NewTypeError("o.a is not a function"),
+		},
+		{
+			code: `var a = [1]; a[0]()`,
+			want: types.NewTypeError("a[0] is not a function"),
+		},
+		{
+			code: `var o = {}; o[b]`,
+			want: types.NewReferenceError("b is not defined"),
+		},
+		{
+			code: `var s = "abc"; s.foo()`,
+			want: types.NewTypeError("s.foo is not a function"),
+		},
+		{
+			code: `var v = ({}).valueOf; v()`,
+			want: types.NewTypeError("Cannot convert undefined or null to object"),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		_, err = js.Eval(tc.code)
+		assert.EqualErrs(t, tc.want, err, "evaluating %s", tc.code)
+	}
+}
+
+func TestNewEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: `function F() {} F.prototype.constructor === F`, want: types.True},
+		{code: `var f = function () {}; f.prototype.constructor === f`, want: types.True},
+		{code: `function F() {} new F().constructor === F`, want: types.True},
+		{code: `function F() {} new F.prototype.constructor().constructor === F`, want: types.True},
+		{code: `function F() {} F.prototype.a = 1; var o = new F; o.a`, want: types.Number(1)},
+		{code: `function F() {} F.prototype.a = 1; var o = new F(); o.a = 2; F.prototype.a`, want: types.Number(1)},
+		{
+			code: `function F() {} F.prototype.get = function () { return 7 }; new F().get()`,
+			want: types.Number(7),
+		},
+		{code: `function F() {} var o = new F(); var s = ""; for (var k in o) s += k; s`, want: types.NewString("")},
+		{code: `function F() { return {a: 3} } new F().a`, want: types.Number(3)},
+		{code: `function F() { return 3 } new F().constructor === F`, want: types.True},
+		{code: `function F() {} F.prototype = 1; "" + new F()`, want: types.NewString("[object Object]")},
+		{code: `function F() {} F.prototype = {b: 2}; new F().b`, want: types.Number(2)},
+		{code: `var n = 0; function F(a, b) { n = a + b } new F(1, 2); n`, want: types.Number(3)},
+		{code: `function F() {} new F() === new F()`, want: types.False},
+		{code: `function F() { return function () { return 5 } } new F()()`, want: types.Number(5)},
+		{code: `new Array(3).length`, want: types.Number(3)},
+		{code: `"" + new Array(1, 2)`, want: types.NewString("1,2")},
+		{code: `Array.isArray(new Array)`, want: types.True},
+		{code: `new function () { return {a: 1} }().a`, want: types.Number(1)},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestNewEvalErrors(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want error
+	}{
+		{
+			code: `new console.log`,
+			want: types.NewTypeError("console.log is not a constructor"),
+		},
+		{
+			code: `new console.log("a")`,
+			want: types.NewTypeError("console.log is not a constructor"),
+		},
+		{
+			code: `var a = 1; new a()`,
+			want: types.NewTypeError("a is not a constructor"),
+		},
+		{
+			code: `var o = {}; new o`,
+			want: types.NewTypeError("o is not a constructor"),
+		},
+		{
+			code: `new Array.isArray([])`,
+			want: types.NewTypeError("Array.isArray is not a constructor"),
+		},
+		{
+			code: `new b`,
+			want: types.NewReferenceError("b is not defined"),
+		},
+		{
+			code: `function F() { throw 1 } new F()`,
+			want: types.NewThrownValue(types.NewNumber(1)),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		_, err = js.Eval(tc.code)
+		assert.EqualErrs(t, tc.want, err, "evaluating %s", tc.code)
+	}
+}
+
+func TestThisEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: `this.console === console`, want: types.True},
+		{code: `var a = 1; this.a`, want: types.Number(1)},
+		{code: `this.b = 2; b`, want: types.Number(2)},
+		{code: `function f() { return this } f() === this`, want: types.True},
+		{code: `var f = function () { return this }; f() === this`, want: types.True},
+		{code: `var o = {f: function () { return this }}; o.f() === o`, want: types.True},
+		{code: `var o = {f: function () { return this }}; o["f"]() === o`, want: types.True},
+		{code: `var o = {f: function () { return this }}; (o.f)() === o`, want: types.True},
+		{code: `var o = {f: function () { return this }}; var f = o.f; f() === this`, want: types.True},
+		{code: `var o = {f: function () { return this }}; (0, o.f)() === this`, want: types.True},
+		{code: `var o = {a: {f: function () { return this }}}; o.a.f() === o.a`, want: types.True},
+		{code: `var a = [function () { return this }]; a[0]() === a`, want: types.True},
+		{
+			code: `var o = {n: 0, inc: function () { this.n += 1; return this }}; o.inc().inc().n`,
+			want: types.Number(2),
+		},
+		{
+			code: `var o = {f: function () { return function () { return this }() }}; o.f() === this`,
+			want: types.True,
+		},
+		{code: `var o = {get a() { return this.b }, b: 3}; o.a`, want: types.Number(3)},
+		{code: `var v; var o = {set a(x) { this.b = x }}; o.a = 4; o.b`, want: types.Number(4)},
+		{code: `function P(x) { this.x = x } new P(5).x`, want: types.Number(5)},
+		{
+			code: `function P(x) { this.x = x } P.prototype.get = function () { return this.x }; new P(6).get()`,
+			want: types.Number(6),
+		},
+		{code: `var o = {f: function () { return this }}; try { throw o } catch (e) { e.f() === o }`, want: types.True},
+		{code: `[1, 2].join("-")`, want: types.NewString("1-2")},
+		{code: `var o = {}; o.toString()`, want: types.NewString("[object Object]")},
+		{code: `[1, [2, 3]].toString()`, want: types.NewString("1,2,3")},
+		{
+			code: `var a = [1]; a.valueOf = function () { return this === a }; a + ""`,
+			want: types.NewString("true"),
+		},
+		{
+			code: `var a = [1]; a.toString = function () { return this === a }; a + ""`,
+			want: types.NewString("true"),
+		},
+		{
+			code: `function f() {} f.valueOf = function () { return this === f }; f + ""`,
+			want: types.NewString("true"),
+		},
+		{
+			code: `var a = (function () { return arguments })(); a.valueOf = function () { return this === a }; a + ""`,
+			want: types.NewString("true"),
+		},
+		{
+			code: `Array.valueOf = function () { return this === Array }; Array + ""`,
+			want: types.NewString("true"),
+		},
+		{
+			code: `var l = console.log; l.valueOf = function () { return this === l }; l + ""`,
+			want: types.NewString("true"),
+		},
+		{
+			code: `console.valueOf = function () { return this === console }; console + ""`,
+			want: types.NewString("true"),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestArgumentsEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: `function f() { return arguments.length } f()`, want: types.Number(0)},
+		{code: `function f(a) { return arguments.length } f(1, 2, 3)`, want: types.Number(3)},
+		{code: `function f() { return arguments[1] } f(1, 2)`, want: types.Number(2)},
+		{code: `function f() { return arguments[2] } f(1, 2)`, want: types.Undefined},
+		{code: `function f() { return arguments.callee === f } f()`, want: types.True},
+		{code: `function f() { return "" + arguments } f()`, want: types.NewString("[object Arguments]")},
+		{code: `function f() { return typeof arguments } f()`, want: types.NewString("object")},
+		{code: `function f(a) { a = 2; return arguments[0] } f(1)`, want: types.Number(2)},
+		{code: `function f(a) { arguments[0] = 2; return a } f(1)`, want: types.Number(2)},
+		{code: `function f(a) { arguments[0]++; return a } f(1)`, want: types.Number(2)},
+		{code: `function f(a, b) { b = 2; return arguments[1] } f(1)`, want: types.Undefined},
+		{code: `function f(a, b) { arguments[1] = 2; return b } f(1)`, want: types.Undefined},
+		{code: `function f(a) { delete arguments[0]; arguments[0] = 2; return a } f(1)`, want: types.Number(1)},
+		{code: `function f(a, a) { arguments[1] = 3; return a } f(1, 2)`, want: types.Number(3)},
+		{code: `function f(a, a) { arguments[0] = 3; return a } f(1, 2)`, want: types.Number(2)},
+		{code: `function f(arguments) { return arguments } f(1)`, want: types.Number(1)},
+		{code: `function f() { function arguments() {} return typeof arguments } f()`, want: types.NewString("function")},
+		{code: `function f() { var arguments; return typeof arguments } f()`, want: types.NewString("object")},
+		{code: `function f() { arguments = 1; return arguments } f()`, want: types.Number(1)},
+		{code: `function f() { return function () { return arguments[0] }(2) } f(1)`, want: types.Number(2)},
+		{
+			code: `function f() { var k = ""; for (var i in arguments) { k += i } return k } f(1, 2)`,
+			want: types.NewString("01"),
+		},
+		{code: `typeof arguments`, want: types.NewString("undefined")},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestUnaryKeywordEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: `typeof 1`, want: types.NewString("number")},
+		{code: `typeof "a"`, want: types.NewString("string")},
+		{code: `typeof false`, want: types.NewString("boolean")},
+		{code: `typeof undefined`, want: types.NewString("undefined")},
+		{code: `typeof null`, want: types.NewString("object")},
+		{code: `typeof {}`, want: types.NewString("object")},
+		{code: `typeof []`, want: types.NewString("object")},
+		{code: `typeof function () {}`, want: types.NewString("function")},
+		{code: `typeof console.log`, want: types.NewString("function")},
+		{code: `typeof Array`, want: types.NewString("function")},
+		{code: `typeof undeclared`, want: types.NewString("undefined")},
+		{code: `var a; typeof a`, want: types.NewString("undefined")},
+		{code: `typeof typeof 1`, want: types.NewString("string")},
+		{code: `var o = {}; typeof o.missing`, want: types.NewString("undefined")},
+		{code: `void 0`, want: types.Undefined},
+		{code: `var n = 0; void (n = 2); n`, want: types.Number(2)},
+		{code: `var o = {a: 1}; delete o.a`, want: types.True},
+		{code: `var o = {a: 1}; delete o.a; o.a`, want: types.Undefined},
+		{code: `var o = {a: 1}; delete o["a"]; "a" in o`, want: types.False},
+		{code: `var o = {}; delete o.missing`, want: types.True},
+		{code: `var a = [1, 2]; delete a.length`, want: types.False},
+		{code: `var a = [1, 2]; delete a[1]; "" + a.length + (1 in a)`, want: types.NewString("2false")},
+		{code: `function F() {} delete F.prototype`, want: types.False},
+		{code: `var x = 1; delete x`, want: types.False},
+		{code: `var x = 1; delete x; x`, want: types.Number(1)},
+		{code: `y = 1; delete y`, want: types.True},
+		{code: `y = 1; delete y; typeof y`, want: types.NewString("undefined")},
+		{code: `function f() {} delete f`, want: types.False},
+		{code: `function f(a) { return delete a } f(1)`, want: types.False},
+		{code: `delete undeclared`, want: types.True},
+		{code: `delete 1`, want: types.True},
+		{code: `var n = 0; delete (n = 1); n`, want: types.Number(1)},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestUpdateEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: `var a = 1; a++`, want: types.Number(1)},
+		{code: `var a = 1; a++; a`, want: types.Number(2)},
+		{code: `var a = 1; ++a`, want: types.Number(2)},
+		{code: `var a = 1; a--`, want: types.Number(1)},
+		{code: `var a = 1; a--; a`, want: types.Number(0)},
+		{code: `var a = 1; --a`, want: types.Number(0)},
+		{code: `var a = "1"; a++`, want: types.Number(1)},
+		{code: `var a = "1"; a++; a`, want: types.Number(2)},
+		{code: `var a = "a"; ++a`, want: types.Number(math.NaN())},
+		{code: `var a; a++`, want: types.Number(math.NaN())},
+		{code: `var a = null; ++a`, want: types.Number(1)},
+		{code: `var a = true; a--`, want: types.Number(1)},
+		{code: `var o = {count: 1}; --o.count; o.count`, want: types.Number(0)},
+		{code: `var o = {}; o.count++; o.count`, want: types.Number(math.NaN())},
+		{code: `var a = [1, 2]; var i = 1; a[i]++; "" + a`, want: types.NewString("1,3")},
+		{
+			code: `var o = {valueOf: function () { throw "v" }}; try { o++ } catch (e) {}; typeof o`,
+			want: types.NewString("object"),
+		},
+		{code: `var a = [1]; a[a.length]--; a.length`, want: types.Number(2)},
+		{code: `var a = 1; var b = a++ + ++a; "" + a + b`, want: types.NewString("34")},
+		{code: `var a = 1, b = 1; a
+++b; "" + a + b`, want: types.NewString("12")},
+		{code: `undeclared = 1; undeclared++; undeclared`, want: types.Number(2)},
+		{code: `function f() { var n = 0; n++; return n } f()`, want: types.Number(1)},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestUpdateEvalErrors(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want error
+	}{
+		{
+			code: `1++`,
+			want: E("parser error: <interactive>:1:0: " +
+				"Invalid left-hand side expression in postfix operation"),
+		},
+		{
+			code: `--"a"`,
+			want: E("parser error: <interactive>:1:0: " +
+				"Invalid left-hand side expression in prefix operation"),
+		},
+		{
+			code: `function f() {} f()++`,
+			want: types.NewReferenceError(
+				"Invalid left-hand side expression in postfix operation"),
+		},
+		{
+			code: `undeclared++`,
+			want: types.NewReferenceError("undeclared is not defined"),
+		},
+		{
+			code: `var o; o.a++`,
+			want: types.NewTypeError("Cannot read property 'a' of undefined"),
+		},
+		{
+			code: `var o = {valueOf: function () { throw "v" }}; o++`,
+			want: types.NewThrownValue(types.NewString("v")),
+		},
+		{
+			code: `var o = {a: {valueOf: function () { throw "v" }}}; --o.a`,
+			want: types.NewThrownValue(types.NewString("v")),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		_, err = js.Eval(tc.code)
+		assert.EqualErrs(t, tc.want, err, "evaluating %s", tc.code)
+	}
+}
+
+func TestRelationalKeywordEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: `"a" in {a: 1}`, want: types.True},
+		{code: `"b" in {a: 1}`, want: types.False},
+		{code: `"toString" in {}`, want: types.True},
+		{code: `"a" in {a: undefined}`, want: types.True},
+		{code: `1 in {1: 1}`, want: types.True},
+		{code: `0 in [1]`, want: types.True},
+		{code: `1 in [1]`, want: types.False},
+		{code: `"length" in []`, want: types.True},
+		{code: `"prototype" in function () {}`, want: types.True},
+		{code: `var k = {toString: function () { return "a" }}; k in {a: 1}`, want: types.True},
+		{code: `function F() {} new F() instanceof F`, want: types.True},
+		{code: `function F() {} function G() {} new F() instanceof G`, want: types.False},
+		{code: `function F() {} ({}) instanceof F`, want: types.False},
+		{code: `function F() {} 1 instanceof F`, want: types.False},
+		{code: `[] instanceof Array`, want: types.True},
+		{code: `({}) instanceof Array`, want: types.False},
+		{
+			code: `function A() {} function B() {} B.prototype = new A(); new B() instanceof A`,
+			want: types.True,
+		},
+		{
+			code: `function F() {} var f = new F(); F.prototype = {}; f instanceof F`,
+			want: types.False,
+		},
+		{code: `var o = {}; for (var i = ("a" in o); i;) {} i`, want: types.False},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestRelationalKeywordEvalErrors(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want error
+	}{
+		{
+			code: `"a" in 1`,
+			want: types.NewTypeError("Cannot use 'in' operator to search for 'a' in 1"),
+		},
+		{
+			code: `"a" in undefined`,
+			want: types.NewTypeError("Cannot use 'in' operator to search for 'a' in undefined"),
+		},
+		{
+			code: `({}) instanceof 1`,
+			want: types.NewTypeError("Right-hand side of 'instanceof' is not an object"),
+		},
+		{
+			code: `({}) instanceof {}`,
+			want: types.NewTypeError("Right-hand side of 'instanceof' is not callable"),
+		},
+		{
+			code: `function F() {} F.prototype = 1; ({}) instanceof F`,
+			want: types.NewTypeError("Function has non-object prototype '1' in instanceof check"),
+		},
+		{
+			code: `typeof undeclared.a`,
+			want: types.NewReferenceError("undeclared is not defined"),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		_, err = js.Eval(tc.code)
+		assert.EqualErrs(t, tc.want, err, "evaluating %s", tc.code)
+	}
+}
+
+func TestStrictModeEval(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want types.Value
+	}{
+		{code: `"use strict"`, want: types.NewString("use strict")},
+		{code: `"use strict"; var a = 1; a`, want: types.Number(1)},
+		{code: `"use strict"; function f() { return this } f()`, want: types.Undefined},
+		{code: `function f() { "use strict"; return this } f()`, want: types.Undefined},
+		{code: `function f() { return this } f() === this`, want: types.True},
+		{
+			code: `function f() { "use strict"; return function () { return this }() } f()`,
+			want: types.Undefined,
+		},
+		{code: `"use strict"; var o = {f: function () { return this }}; o.f() === o`, want: types.True},
+		{code: `function f() { "use strict"; undeclared = 1 } try { f() } catch (e) { e.name }`, want: types.NewString("ReferenceError")},
+		{code: `function f() { undeclared = 1 } f(); undeclared`, want: types.Number(1)},
+		{code: `function f() { "use strict"; var a; a = 1; return a } f()`, want: types.Number(1)},
+		{code: `function f(a) { "use strict"; a = 2; return arguments[0] } f(1)`, want: types.Number(1)},
+		{code: `function f(a) { "use strict"; arguments[0] = 2; return a } f(1)`, want: types.Number(1)},
+		{code: `function f() { "use strict"; return arguments.length } f(1, 2)`, want: types.Number(2)},
+		{code: `var a = [1]; function f() { "use strict"; delete a[0] } f(); 0 in a`, want: types.False},
+		{code: `function f(a, a) { return a } f(1, 2)`, want: types.Number(2)},
+		{code: `var eval = 1; eval`, want: types.Number(1)},
+		{code: `"a"; "use strict"; this === undefined`, want: types.False},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		got, err := js.Eval(tc.code)
+		assert.NoError(t, err, "unexpected error evaluating %s", tc.code)
+
+		assertEqualValues(t, tc.want, got, tc.code)
+	}
+}
+
+func TestStrictModeEvalErrors(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want error
+	}{
+		{
+			code: `"use strict"; undeclared = 1`,
+			want: types.NewReferenceError("undeclared is not defined"),
+		},
+		{
+			code: `function f() { "use strict"; undeclared++ } f()`,
+			want: types.NewReferenceError("undeclared is not defined"),
+		},
+		{
+			code: `"use strict"; Array.prototype = {}`,
+			want: types.NewTypeError("Cannot assign to read only property 'prototype'"),
+		},
+		{
+			code: `"use strict"; var a = [1]; a.length = 0; Array.isArray = 1; delete a.length`,
+			want: types.NewTypeError("Cannot delete property 'length' of Array"),
+		},
+		{
+			code: `"use strict"; (function f() { f = 1 })()`,
+			want: types.NewTypeError("Assignment to constant variable f"),
+		},
+		{
+			code: `"use strict"; var s = "a"; s.b = 1`,
+			want: types.NewTypeError("Cannot create property 'b' on a"),
+		},
+		{
+			code: `function f() { "use strict"; return arguments.callee } f()`,
+			want: types.NewTypeError("'caller', 'callee', and 'arguments' " +
+				"properties may not be accessed on strict mode functions or " +
+				"the arguments objects for calls to them"),
+		},
+	} {
+		js, err := abad.NewAbad()
+		assert.NoError(t, err, "failed to start interpreter")
+
+		_, err = js.Eval(tc.code)
+		assert.EqualErrs(t, tc.want, err, "evaluating %s", tc.code)
+	}
+}
@@ -21,9 +21,11 @@ type (
 		Equal(other Node) bool
 	}
 
-	// Program Abstract Syntax Tree
+	// Program Abstract Syntax Tree, also used for function bodies.
+	// Strict tells if it's strict mode code.
 	Program struct {
-		Nodes []Node
+		Nodes  []Node
+		Strict bool
 	}
 
 	Number float64
@@ -36,17 +38,80 @@ type (
 
 	Null struct{}
 
+	// This is the this keyword.
+	This struct{}
+
 	// UnaryExpr is a unary expression (-a, +a, ~a, and so on)
 	UnaryExpr struct {
 		Operator token.Type
 		Operand  Node
 	}
 
+	// UpdateExpr is an increment or decrement expression, prefix
+	// (++a, --a) or postfix (a++, a--).
+	UpdateExpr struct {
+		Operator token.Type
+		Operand  Node
+		Prefix   bool
+	}
+
+	// BinaryExpr is a binary expression (a + b, a * b, and so on)
+	BinaryExpr struct {
+		Operator token.Type
+		Left     Node
+		Right    Node
+	}
+
+	// AssignExpr is an assignment expression (a = b, a += b, and so on)
+	AssignExpr struct {
+		Operator token.Type
+		Target   Node
+		Value    Node
+	}
+
+	// ConditionalExpr is the conditional operator (a ? b : c)
+	ConditionalExpr struct {
+		Cond Node
+		Then Node
+		Else Node
+	}
+
+	// SequenceExpr is the comma operator (a, b, c)
+	SequenceExpr struct {
+		Exprs []Node
+	}
+
+	// PropertyKind tells how a property of an object
+	// literal is defined.
+	PropertyKind int
+
+	// Property is a property definition of an object literal,
+	// the Value of getters and setters is a FunExpr.
+	Property struct {
+		Kind  PropertyKind
+		Name  utf16.Str
+		Value Node
+	}
+
+	// ObjectLiteral is the object initializer ({a: 1, get b() {}})
+	ObjectLiteral struct {
+		Props []Property
+	}
+
+	// ArrayLiteral is the array initializer ([1, , 3]), the
+	// elisions (holes) are nil elements.
+	ArrayLiteral struct {
+		Elems []Node
+	}
+
 	// MemberExpr handles get of object's properties
-	// eg.: <object>.<property>
+	// eg.: <object>.<property> or <object>[<expr>]
+	// The Property of the dot notation is an Ident and the
+	// Computed ones are expressions.
 	MemberExpr struct {
 		Object   Node
-		Property Ident
+		Property Node
+		Computed bool
 	}
 
 	CallExpr struct {
@@ -54,6 +119,13 @@ type (
 		Args   []Node
 	}
 
+	// NewExpr is the new operator (new F(a, b)), Args is
+	// empty when the arguments are omitted (new F).
+	NewExpr struct {
+		Callee Node
+		Args   []Node
+	}
+
 	// FunDecl is the syntatic function declaration
 	FunDecl struct {
 		Name Ident
@@ -61,8 +133,113 @@ type (
 		Body *Program
 	}
 
+	// FunExpr is a function expression, Name is empty
+	// for anonymous functions.
+	FunExpr struct {
+		Name Ident
+		Args []Ident
+		Body *Program
+	}
+
+	// ReturnStmt is the return statement, Value is nil
+	// when no expression is returned.
+	ReturnStmt struct {
+		Value Node
+	}
+
+	// BlockStmt is a list of statements delimited by braces
+	BlockStmt struct {
+		Nodes []Node
+	}
+
+	// IfStmt is the if statement, Else is nil when
+	// there's no else clause.
+	IfStmt struct {
+		Cond Node
+		Then Node
+		Else Node
+	}
+
+	// WhileStmt is the while loop
+	WhileStmt struct {
+		Cond Node
+		Body Node
+	}
+
+	// DoWhileStmt is the do-while loop
+	DoWhileStmt struct {
+		Body Node
+		Cond Node
+	}
+
+	// ForStmt is the C like for loop, any of Init,
+	// Cond and Post are nil when omitted.
+	ForStmt struct {
+		Init Node
+		Cond Node
+		Post Node
+		Body Node
+	}
+
+	// ForInStmt is the for-in loop, Target is a VarDecls
+	// with a single declaration or a left-hand side expression.
+	ForInStmt struct {
+		Target Node
+		Object Node
+		Body   Node
+	}
+
+	// SwitchStmt is the switch statement, the clauses are
+	// kept in the source order.
+	SwitchStmt struct {
+		Discriminant Node
+		Cases        []CaseClause
+	}
+
+	// CaseClause is a clause of the switch statement, Test
+	// is nil for the default clause.
+	CaseClause struct {
+		Test Node
+		Body []Node
+	}
+
+	// ThrowStmt is the throw statement
+	ThrowStmt struct {
+		Value Node
+	}
+
+	// TryStmt is the try statement, Catch is nil when there's
+	// no catch clause and Finally is nil when there's no
+	// finally clause.
+	TryStmt struct {
+		Body    *BlockStmt
+		Param   Ident
+		Catch   *BlockStmt
+		Finally *BlockStmt
+	}
+
+	// LabeledStmt is a statement prefixed by a label
+	LabeledStmt struct {
+		Label Ident
+		Body  Node
+	}
+
+	// BreakStmt is the break statement, Label is
+	// empty when not provided.
+	BreakStmt struct {
+		Label Ident
+	}
+
+	// ContinueStmt is the continue statement, Label
+	// is empty when not provided.
+	ContinueStmt struct {
+		Label Ident
+	}
+
 	Ident utf16.Str
 
+	// VarDecl is a variable declaration, Value is nil when
+	// the declaration has no initializer.
 	VarDecl struct {
 		Name  Ident
 		Value Node
@@ -76,6 +253,19 @@ const (
 	NodeFunDecl
 	NodeVarDecl
 	NodeVarDecls
+	NodeReturnStmt
+	NodeBlockStmt
+	NodeIfStmt
+	NodeWhileStmt
+	NodeDoWhileStmt
+	NodeForStmt
+	NodeForInStmt
+	NodeSwitchStmt
+	NodeThrowStmt
+	NodeTryStmt
+	NodeLabeledStmt
+	NodeBreakStmt
+	NodeContinueStmt
 
 	exprBegin
 
@@ -84,9 +274,19 @@ const (
 	NodeNull
 	NodeUndefined
 	NodeBool
+	NodeThis
 	NodeUnaryExpr
+	NodeUpdateExpr
+	NodeBinaryExpr
+	NodeAssignExpr
+	NodeConditionalExpr
+	NodeSequenceExpr
+	NodeObjectLiteral
+	NodeArrayLiteral
 	NodeMemberExpr
 	NodeCallExpr
+	NodeNewExpr
+	NodeFunExpr
 	NodeIdent
 
 	exprEnd
@@ -94,21 +294,50 @@ const (
 	endNodeTypes
 )
 
+const (
+	PropInit PropertyKind = iota
+	PropGet
+	PropSet
+)
+
 var nodeTypesNames = [...]string{
-	NodeProgram:    "PROGRAM",
-	NodeFunDecl:    "FUNDECL",
-	NodeVarDecl:    "VARDECL",
-	NodeVarDecls:   "VARDECLS",
-	NodeNumber:     "NUMBER",
-	NodeString:     "STRING",
-	NodeBool:       "BOOLEAN",
-	NodeUndefined:  "UNDEFINED",
-	NodeNull:       "NULL",
-	NodeUnaryExpr:  "UNARYEXPR",
-	NodeMemberExpr: "MEMBEREXPR",
-	NodeCallExpr:   "CALLEXPR",
-	NodeIdent:      "IDENT",
-	exprEnd:        "",
+	NodeProgram:         "PROGRAM",
+	NodeFunDecl:         "FUNDECL",
+	NodeVarDecl:         "VARDECL",
+	NodeVarDecls:        "VARDECLS",
+	NodeReturnStmt:      "RETURNSTMT",
+	NodeBlockStmt:       "BLOCKSTMT",
+	NodeIfStmt:          "IFSTMT",
+	NodeWhileStmt:       "WHILESTMT",
+	NodeDoWhileStmt:     "DOWHILESTMT",
+	NodeForStmt:         "FORSTMT",
+	NodeForInStmt:       "FORINSTMT",
+	NodeSwitchStmt:      "SWITCHSTMT",
+	NodeThrowStmt:       "THROWSTMT",
+	NodeTryStmt:         "TRYSTMT",
+	NodeLabeledStmt:     "LABELEDSTMT",
+	NodeBreakStmt:       "BREAKSTMT",
+	NodeContinueStmt:    "CONTINUESTMT",
+	NodeNumber:          "NUMBER",
+	NodeString:          "STRING",
+	NodeBool:            "BOOLEAN",
+	NodeThis:            "THIS",
+	NodeUndefined:       "UNDEFINED",
+	NodeNull:            "NULL",
+	NodeUnaryExpr:       "UNARYEXPR",
+	NodeUpdateExpr:      "UPDATEEXPR",
+	NodeBinaryExpr:      "BINARYEXPR",
+	NodeAssignExpr:      "ASSIGNEXPR",
+	NodeConditionalExpr: "CONDITIONALEXPR",
+	NodeSequenceExpr:    "SEQUENCEEXPR",
+	NodeObjectLiteral:   "OBJECTLITERAL",
+	NodeArrayLiteral:    "ARRAYLITERAL",
+	NodeMemberExpr:      "MEMBEREXPR",
+	NodeCallExpr:        "CALLEXPR",
+	NodeNewExpr:         "NEWEXPR",
+	NodeFunExpr:         "FUNEXPR",
+	NodeIdent:           "IDENT",
+	exprEnd:             "",
 }
 
 // console.log(Number.EPSILON);
@@ -145,7 +374,7 @@ func (p *Program) Equal(other Node) bool {
 	}
 
 	o := other.(*Program)
-	if len(p.Nodes) != len(o.Nodes) {
+	if p.Strict != o.Strict || len(p.Nodes) != len(o.Nodes) {
 		return false
 	}
 
@@ -236,6 +465,23 @@ func (Null) String() string {
 	return "null"
 }
 
+func NewThis() This {
+	return This{}
+}
+
+func (This) Equal(other Node) bool {
+	_, ok := other.(This)
+	return ok
+}
+
+func (This) Type() NodeType {
+	return NodeThis
+}
+
+func (This) String() string {
+	return "this"
+}
+
 func NewNumber(a float64) Number {
 	return Number(a)
 }
@@ -277,6 +523,10 @@ func (_ *UnaryExpr) Type() NodeType {
 }
 
 func (a *UnaryExpr) String() string {
+	if token.IsKeyword(a.Operator) {
+		return fmt.Sprintf("%s %s", operator(a.Operator), a.Operand)
+	}
+
 	return fmt.Sprintf("%s%s", a.Operator, a.Operand)
 }
 
@@ -293,6 +543,280 @@ func (a *UnaryExpr) Equal(other Node) bool {
 	return a.Operand.Equal(o.Operand)
 }
 
+func NewUpdateExpr(operator token.Type, operand Node, prefix bool) *UpdateExpr {
+	return &UpdateExpr{
+		Operator: operator,
+		Operand:  operand,
+		Prefix:   prefix,
+	}
+}
+
+func (_ *UpdateExpr) Type() NodeType {
+	return NodeUpdateExpr
+}
+
+func (a *UpdateExpr) String() string {
+	if a.Prefix {
+		return fmt.Sprintf("%s%s", a.Operator, a.Operand)
+	}
+
+	return fmt.Sprintf("%s%s", a.Operand, a.Operator)
+}
+
+func (a *UpdateExpr) Equal(other Node) bool {
+	if other.Type() != a.Type() {
+		return false
+	}
+
+	o := other.(*UpdateExpr)
+	if a.Operator != o.Operator || a.Prefix != o.Prefix {
+		return false
+	}
+
+	return a.Operand.Equal(o.Operand)
+}
+
+func NewBinaryExpr(operator token.Type, left, right Node) *BinaryExpr {
+	return &BinaryExpr{
+		Operator: operator,
+		Left:     left,
+		Right:    right,
+	}
+}
+
+func (_ *BinaryExpr) Type() NodeType {
+	return NodeBinaryExpr
+}
+
+func (a *BinaryExpr) String() string {
+	return fmt.Sprintf("(%s %s %s)", a.Left, operator(a.Operator), a.Right)
+}
+
+// operator returns the operator t as written in the source,
+// the keyword operators (eg.: typeof) are in lower case.
+func operator(t token.Type) string {
+	if token.IsKeyword(t) {
+		return strings.ToLower(t.String())
+	}
+
+	return t.String()
+}
+
+func (a *BinaryExpr) Equal(other Node) bool {
+	if other.Type() != a.Type() {
+		return false
+	}
+
+	o := other.(*BinaryExpr)
+	if a.Operator != o.Operator {
+		return false
+	}
+
+	return a.Left.Equal(o.Left) && a.Right.Equal(o.Right)
+}
+
+func NewAssignExpr(operator token.Type, target, value Node) *AssignExpr {
+	return &AssignExpr{
+		Operator: operator,
+		Target:   target,
+		Value:    value,
+	}
+}
+
+func (_ *AssignExpr) Type() NodeType {
+	return NodeAssignExpr
+}
+
+func (a *AssignExpr) String() string {
+	return fmt.Sprintf("(%s %s %s)", a.Target, a.Operator, a.Value)
+}
+
+func (a *AssignExpr) Equal(other Node) bool {
+	if other.Type() != a.Type() {
+		return false
+	}
+
+	o := other.(*AssignExpr)
+	if a.Operator != o.Operator {
+		return false
+	}
+
+	return a.Target.Equal(o.Target) && a.Value.Equal(o.Value)
+}
+
+func NewConditionalExpr(cond, then, els Node) *ConditionalExpr {
+	return &ConditionalExpr{
+		Cond: cond,
+		Then: then,
+		Else: els,
+	}
+}
+
+func (_ *ConditionalExpr) Type() NodeType {
+	return NodeConditionalExpr
+}
+
+func (c *ConditionalExpr) String() string {
+	return fmt.Sprintf("(%s ? %s : %s)", c.Cond, c.Then, c.Else)
+}
+
+func (c *ConditionalExpr) Equal(other Node) bool {
+	if other.Type() != c.Type() {
+		return false
+	}
+
+	o := other.(*ConditionalExpr)
+	return c.Cond.Equal(o.Cond) &&
+		c.Then.Equal(o.Then) &&
+		c.Else.Equal(o.Else)
+}
+
+func NewSequenceExpr(exprs []Node) *SequenceExpr {
+	return &SequenceExpr{
+		Exprs: exprs,
+	}
+}
+
+func (_ *SequenceExpr) Type() NodeType {
+	return NodeSequenceExpr
+}
+
+func (s *SequenceExpr) String() string {
+	var exprs []string
+	for _, expr := range s.Exprs {
+		exprs = append(exprs, expr.String())
+	}
+
+	return fmt.Sprintf("(%s)", strings.Join(exprs, ", "))
+}
+
+func (s *SequenceExpr) Equal(other Node) bool {
+	if other.Type() != s.Type() {
+		return false
+	}
+
+	return nodesEqual(s.Exprs, other.(*SequenceExpr).Exprs)
+}
+
+func NewObjectLiteral(props []Property) *ObjectLiteral {
+	return &ObjectLiteral{
+		Props: props,
+	}
+}
+
+func (_ *ObjectLiteral) Type() NodeType {
+	return NodeObjectLiteral
+}
+
+func (o *ObjectLiteral) String() string {
+	var props []string
+	for _, prop := range o.Props {
+		props = append(props, prop.String())
+	}
+
+	return fmt.Sprintf("{%s}", strings.Join(props, ", "))
+}
+
+func (o *ObjectLiteral) Equal(other Node) bool {
+	if other.Type() != o.Type() {
+		return false
+	}
+
+	props := other.(*ObjectLiteral).Props
+	if len(o.Props) != len(props) {
+		return false
+	}
+
+	for i, prop := range o.Props {
+		if !prop.Equal(props[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func NewArrayLiteral(elems []Node) *ArrayLiteral {
+	return &ArrayLiteral{
+		Elems: elems,
+	}
+}
+
+func (_ *ArrayLiteral) Type() NodeType {
+	return NodeArrayLiteral
+}
+
+func (a *ArrayLiteral) String() string {
+	var elems []string
+	for _, elem := range a.Elems {
+		if elem == nil {
+			elems = append(elems, "")
+			continue
+		}
+
+		elems = append(elems, elem.String())
+	}
+
+	// a trailing hole needs the comma of the elision
+	if len(a.Elems) > 0 && a.Elems[len(a.Elems)-1] == nil {
+		elems = append(elems, "")
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(elems, ", "))
+}
+
+func (a *ArrayLiteral) Equal(other Node) bool {
+	if other.Type() != a.Type() {
+		return false
+	}
+
+	elems := other.(*ArrayLiteral).Elems
+	if len(a.Elems) != len(elems) {
+		return false
+	}
+
+	for i, elem := range a.Elems {
+		if elem == nil || elems[i] == nil {
+			if elem != elems[i] {
+				return false
+			}
+
+			continue
+		}
+
+		if !elem.Equal(elems[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func NewProperty(kind PropertyKind, name utf16.Str, value Node) Property {
+	return Property{
+		Kind:  kind,
+		Name:  name,
+		Value: value,
+	}
+}
+
+func (p Property) String() string {
+	switch p.Kind {
+	case PropGet:
+		return fmt.Sprintf("get %s %s", p.Name, p.Value)
+	case PropSet:
+		return fmt.Sprintf("set %s %s", p.Name, p.Value)
+	}
+
+	return fmt.Sprintf("%s: %s", p.Name, p.Value)
+}
+
+func (p Property) Equal(o Property) bool {
+	return p.Kind == o.Kind &&
+		p.Name.Equal(o.Name) &&
+		p.Value.Equal(o.Value)
+}
+
 func NewIdent(ident utf16.Str) Ident {
 	return Ident(ident)
 }
@@ -333,8 +857,22 @@ func NewMemberExpr(object Node, property Ident) *MemberExpr {
 	}
 }
 
+// NewComputedMemberExpr creates the property access
+// <object>[<property>].
+func NewComputedMemberExpr(object Node, property Node) *MemberExpr {
+	return &MemberExpr{
+		Object:   object,
+		Property: property,
+		Computed: true,
+	}
+}
+
 func (m *MemberExpr) Type() NodeType { return NodeMemberExpr }
 func (m *MemberExpr) String() string {
+	if m.Computed {
+		return fmt.Sprintf("%s[%s]", m.Object, m.Property)
+	}
+
 	return fmt.Sprintf("%s.%s", m.Object, m.Property)
 }
 
@@ -344,7 +882,8 @@ func (m *MemberExpr) Equal(other Node) bool {
 	}
 
 	o := other.(*MemberExpr)
-	return m.Object.Equal(o.Object) &&
+	return m.Computed == o.Computed &&
+		m.Object.Equal(o.Object) &&
 		m.Property.Equal(o.Property)
 }
 
@@ -363,11 +902,27 @@ func (v VarDecl) Equal(other Node) bool {
 	}
 
 	o := other.(VarDecl)
-	return v.Name.Equal(o.Name) && v.Value.Equal(o.Value)
+	if !v.Name.Equal(o.Name) {
+		return false
+	}
+
+	if v.Value == nil || o.Value == nil {
+		return v.Value == nil && o.Value == nil
+	}
+
+	return v.Value.Equal(o.Value)
 }
 
 func (v VarDecl) String() string {
-	return fmt.Sprintf("var %s = %s", v.Name, v.Value)
+	return "var " + v.decl()
+}
+
+func (v VarDecl) decl() string {
+	if v.Value == nil {
+		return v.Name.String()
+	}
+
+	return fmt.Sprintf("%s = %s", v.Name, v.Value)
 }
 
 func NewVarDecls(vars ...VarDecl) VarDecls {
@@ -399,7 +954,7 @@ func (v VarDecls) Equal(other Node) bool {
 func (v VarDecls) String() string {
 	varstr := []string{}
 	for _, vardecl := range v {
-		varstr = append(varstr, fmt.Sprintf("%s = %s", vardecl.Name, vardecl.Value))
+		varstr = append(varstr, vardecl.decl())
 	}
 	return "var " + strings.Join(varstr, ",")
 }
@@ -436,6 +991,28 @@ func (c *CallExpr) Equal(other Node) bool {
 	return c.Callee.Equal(o.Callee)
 }
 
+func NewNewExpr(callee Node, args []Node) *NewExpr {
+	return &NewExpr{
+		Callee: callee,
+		Args:   args,
+	}
+}
+
+func (n *NewExpr) Type() NodeType { return NodeNewExpr }
+func (n *NewExpr) String() string {
+	return fmt.Sprintf("new %s(<args>)", n.Callee)
+}
+
+func (n *NewExpr) Equal(other Node) bool {
+	if other.Type() != n.Type() {
+		return false
+	}
+
+	o := other.(*NewExpr)
+	return n.Callee.Equal(o.Callee) &&
+		nodesEqual(n.Args, o.Args)
+}
+
 // NewFunDecl creates a new function declaration node.
 func NewFunDecl(name Ident, args []Ident, body *Program) *FunDecl {
 	return &FunDecl{
@@ -484,6 +1061,480 @@ func (a *FunDecl) Equal(other Node) bool {
 	return a.Name.Equal(o.Name) && a.Body.Equal(o.Body)
 }
 
+// NewFunExpr creates a new function expression node.
+func NewFunExpr(name Ident, args []Ident, body *Program) *FunExpr {
+	return &FunExpr{
+		Name: name,
+		Args: args,
+		Body: body,
+	}
+}
+
+func (a *FunExpr) Type() NodeType {
+	return NodeFunExpr
+}
+
+func (a *FunExpr) String() string {
+	return fmt.Sprintf("(%s)", NewFunDecl(a.Name, a.Args, a.Body))
+}
+
+func (a *FunExpr) Equal(other Node) bool {
+	if other.Type() != NodeFunExpr {
+		return false
+	}
+
+	o := other.(*FunExpr)
+	return NewFunDecl(a.Name, a.Args, a.Body).Equal(
+		NewFunDecl(o.Name, o.Args, o.Body))
+}
+
+func NewReturnStmt(value Node) *ReturnStmt {
+	return &ReturnStmt{
+		Value: value,
+	}
+}
+
+func (_ *ReturnStmt) Type() NodeType {
+	return NodeReturnStmt
+}
+
+func (r *ReturnStmt) String() string {
+	if r.Value == nil {
+		return "return"
+	}
+
+	return fmt.Sprintf("return %s", r.Value)
+}
+
+func (r *ReturnStmt) Equal(other Node) bool {
+	if other.Type() != r.Type() {
+		return false
+	}
+
+	o := other.(*ReturnStmt)
+	if r.Value == nil || o.Value == nil {
+		return r.Value == nil && o.Value == nil
+	}
+
+	return r.Value.Equal(o.Value)
+}
+
+func NewBlockStmt(nodes []Node) *BlockStmt {
+	return &BlockStmt{
+		Nodes: nodes,
+	}
+}
+
+func (_ *BlockStmt) Type() NodeType {
+	return NodeBlockStmt
+}
+
+func (b *BlockStmt) String() string {
+	var stmts []string
+	for _, stmt := range b.Nodes {
+		stmts = append(stmts, stmt.String())
+	}
+	return fmt.Sprintf("{\n%s\n}", strings.Join(stmts, "\n"))
+}
+
+func (b *BlockStmt) Equal(other Node) bool {
+	if other.Type() != b.Type() {
+		return false
+	}
+
+	o := other.(*BlockStmt)
+	return nodesEqual(b.Nodes, o.Nodes)
+}
+
+func NewIfStmt(cond, then, els Node) *IfStmt {
+	return &IfStmt{
+		Cond: cond,
+		Then: then,
+		Else: els,
+	}
+}
+
+func (_ *IfStmt) Type() NodeType {
+	return NodeIfStmt
+}
+
+func (s *IfStmt) String() string {
+	str := fmt.Sprintf("if (%s) %s", s.Cond, s.Then)
+	if s.Else != nil {
+		str += fmt.Sprintf(" else %s", s.Else)
+	}
+	return str
+}
+
+func (s *IfStmt) Equal(other Node) bool {
+	if other.Type() != s.Type() {
+		return false
+	}
+
+	o := other.(*IfStmt)
+	if !s.Cond.Equal(o.Cond) || !s.Then.Equal(o.Then) {
+		return false
+	}
+
+	if s.Else == nil || o.Else == nil {
+		return s.Else == nil && o.Else == nil
+	}
+
+	return s.Else.Equal(o.Else)
+}
+
+func NewWhileStmt(cond, body Node) *WhileStmt {
+	return &WhileStmt{
+		Cond: cond,
+		Body: body,
+	}
+}
+
+func (_ *WhileStmt) Type() NodeType {
+	return NodeWhileStmt
+}
+
+func (s *WhileStmt) String() string {
+	return fmt.Sprintf("while (%s) %s", s.Cond, s.Body)
+}
+
+func (s *WhileStmt) Equal(other Node) bool {
+	if other.Type() != s.Type() {
+		return false
+	}
+
+	o := other.(*WhileStmt)
+	return s.Cond.Equal(o.Cond) && s.Body.Equal(o.Body)
+}
+
+func NewDoWhileStmt(body, cond Node) *DoWhileStmt {
+	return &DoWhileStmt{
+		Body: body,
+		Cond: cond,
+	}
+}
+
+func (_ *DoWhileStmt) Type() NodeType {
+	return NodeDoWhileStmt
+}
+
+func (s *DoWhileStmt) String() string {
+	return fmt.Sprintf("do %s while (%s)", s.Body, s.Cond)
+}
+
+func (s *DoWhileStmt) Equal(other Node) bool {
+	if other.Type() != s.Type() {
+		return false
+	}
+
+	o := other.(*DoWhileStmt)
+	return s.Body.Equal(o.Body) && s.Cond.Equal(o.Cond)
+}
+
+func NewForStmt(init, cond, post, body Node) *ForStmt {
+	return &ForStmt{
+		Init: init,
+		Cond: cond,
+		Post: post,
+		Body: body,
+	}
+}
+
+func (_ *ForStmt) Type() NodeType {
+	return NodeForStmt
+}
+
+func (s *ForStmt) String() string {
+	str := func(n Node) string {
+		if n == nil {
+			return ""
+		}
+		return n.String()
+	}
+
+	return fmt.Sprintf("for (%s; %s; %s) %s",
+		str(s.Init), str(s.Cond), str(s.Post), s.Body)
+}
+
+func (s *ForStmt) Equal(other Node) bool {
+	if other.Type() != s.Type() {
+		return false
+	}
+
+	o := other.(*ForStmt)
+	return optionalEqual(s.Init, o.Init) &&
+		optionalEqual(s.Cond, o.Cond) &&
+		optionalEqual(s.Post, o.Post) &&
+		s.Body.Equal(o.Body)
+}
+
+func NewForInStmt(target, object, body Node) *ForInStmt {
+	return &ForInStmt{
+		Target: target,
+		Object: object,
+		Body:   body,
+	}
+}
+
+func (_ *ForInStmt) Type() NodeType {
+	return NodeForInStmt
+}
+
+func (s *ForInStmt) String() string {
+	return fmt.Sprintf("for (%s in %s) %s", s.Target, s.Object, s.Body)
+}
+
+func (s *ForInStmt) Equal(other Node) bool {
+	if other.Type() != s.Type() {
+		return false
+	}
+
+	o := other.(*ForInStmt)
+	return s.Target.Equal(o.Target) &&
+		s.Object.Equal(o.Object) &&
+		s.Body.Equal(o.Body)
+}
+
+func NewSwitchStmt(discriminant Node, cases []CaseClause) *SwitchStmt {
+	return &SwitchStmt{
+		Discriminant: discriminant,
+		Cases:        cases,
+	}
+}
+
+func (_ *SwitchStmt) Type() NodeType {
+	return NodeSwitchStmt
+}
+
+func (s *SwitchStmt) String() string {
+	str := fmt.Sprintf("switch (%s) {\n", s.Discriminant)
+	for _, clause := range s.Cases {
+		str += clause.String()
+	}
+
+	return str + "}"
+}
+
+func (s *SwitchStmt) Equal(other Node) bool {
+	if other.Type() != s.Type() {
+		return false
+	}
+
+	o := other.(*SwitchStmt)
+	if !s.Discriminant.Equal(o.Discriminant) ||
+		len(s.Cases) != len(o.Cases) {
+		return false
+	}
+
+	for i, clause := range s.Cases {
+		if !clause.Equal(o.Cases[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Default returns the position of the default clause
+// or -1 if there's none.
+func (s *SwitchStmt) Default() int {
+	for i, clause := range s.Cases {
+		if clause.Test == nil {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func NewCaseClause(test Node, body []Node) CaseClause {
+	return CaseClause{
+		Test: test,
+		Body: body,
+	}
+}
+
+func (c CaseClause) String() string {
+	str := "default:\n"
+	if c.Test != nil {
+		str = fmt.Sprintf("case %s:\n", c.Test)
+	}
+
+	for _, stmt := range c.Body {
+		str += stmt.String() + "\n"
+	}
+
+	return str
+}
+
+func (c CaseClause) Equal(o CaseClause) bool {
+	return optionalEqual(c.Test, o.Test) && nodesEqual(c.Body, o.Body)
+}
+
+func NewThrowStmt(value Node) *ThrowStmt {
+	return &ThrowStmt{
+		Value: value,
+	}
+}
+
+func (_ *ThrowStmt) Type() NodeType {
+	return NodeThrowStmt
+}
+
+func (s *ThrowStmt) String() string {
+	return fmt.Sprintf("throw %s", s.Value)
+}
+
+func (s *ThrowStmt) Equal(other Node) bool {
+	if other.Type() != s.Type() {
+		return false
+	}
+
+	return s.Value.Equal(other.(*ThrowStmt).Value)
+}
+
+func NewTryStmt(body *BlockStmt, param Ident, catch, finally *BlockStmt) *TryStmt {
+	return &TryStmt{
+		Body:    body,
+		Param:   param,
+		Catch:   catch,
+		Finally: finally,
+	}
+}
+
+func (_ *TryStmt) Type() NodeType {
+	return NodeTryStmt
+}
+
+func (s *TryStmt) String() string {
+	str := fmt.Sprintf("try %s", s.Body)
+	if s.Catch != nil {
+		str += fmt.Sprintf(" catch (%s) %s", s.Param, s.Catch)
+	}
+
+	if s.Finally != nil {
+		str += fmt.Sprintf(" finally %s", s.Finally)
+	}
+
+	return str
+}
+
+func (s *TryStmt) Equal(other Node) bool {
+	if other.Type() != s.Type() {
+		return false
+	}
+
+	o := other.(*TryStmt)
+	return s.Body.Equal(o.Body) &&
+		s.Param.Equal(o.Param) &&
+		blockEqual(s.Catch, o.Catch) &&
+		blockEqual(s.Finally, o.Finally)
+}
+
+// blockEqual compares blocks that could be nil.
+func blockEqual(a, b *BlockStmt) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Equal(b)
+}
+
+func NewLabeledStmt(label Ident, body Node) *LabeledStmt {
+	return &LabeledStmt{
+		Label: label,
+		Body:  body,
+	}
+}
+
+func (_ *LabeledStmt) Type() NodeType {
+	return NodeLabeledStmt
+}
+
+func (s *LabeledStmt) String() string {
+	return fmt.Sprintf("%s: %s", s.Label, s.Body)
+}
+
+func (s *LabeledStmt) Equal(other Node) bool {
+	if other.Type() != s.Type() {
+		return false
+	}
+
+	o := other.(*LabeledStmt)
+	return s.Label.Equal(o.Label) && s.Body.Equal(o.Body)
+}
+
+func NewBreakStmt(label Ident) BreakStmt {
+	return BreakStmt{Label: label}
+}
+
+func (_ BreakStmt) Type() NodeType {
+	return NodeBreakStmt
+}
+
+func (s BreakStmt) String() string {
+	if len(s.Label) == 0 {
+		return "break"
+	}
+
+	return fmt.Sprintf("break %s", s.Label)
+}
+
+func (s BreakStmt) Equal(other Node) bool {
+	if other.Type() != s.Type() {
+		return false
+	}
+
+	return s.Label.Equal(other.(BreakStmt).Label)
+}
+
+func NewContinueStmt(label Ident) ContinueStmt {
+	return ContinueStmt{Label: label}
+}
+
+func (_ ContinueStmt) Type() NodeType {
+	return NodeContinueStmt
+}
+
+func (s ContinueStmt) String() string {
+	if len(s.Label) == 0 {
+		return "continue"
+	}
+
+	return fmt.Sprintf("continue %s", s.Label)
+}
+
+func (s ContinueStmt) Equal(other Node) bool {
+	if other.Type() != s.Type() {
+		return false
+	}
+
+	return s.Label.Equal(other.(ContinueStmt).Label)
+}
+
+// optionalEqual compares nodes that could be nil.
+func optionalEqual(a, b Node) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Equal(b)
+}
+
+func nodesEqual(a, b []Node) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := 0; i < len(a); i++ {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func floatEquals(a, b float64) bool {
 	return math.Abs(a-b) < ε && math.Abs(b-a) < ε
 }
@@ -0,0 +1,174 @@
+package builtins
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/NeowayLabs/abad/internal/utf16"
+	"github.com/NeowayLabs/abad/types"
+)
+
+var (
+	lengthAttr      = utf16.S("length")
+	joinAttr        = utf16.S("join")
+	isArrayAttr     = utf16.S("isArray")
+	prototypeAttr   = utf16.S("prototype")
+	constructorAttr = utf16.S("constructor")
+)
+
+// NewArrayPrototype creates the Array prototype object, an array
+// itself, that extends objectProto. Its methods extend fnProto.
+// https://es5.github.io/#x15.4.4
+func NewArrayPrototype(objectProto, fnProto types.Value) (*types.Array, error) {
+	proto := types.NewArray(objectProto)
+
+	for _, method := range []struct {
+		name utf16.Str
+		fn   types.Execfn
+	}{
+		{name: toStringAttr, fn: arrayToString},
+		{name: joinAttr, fn: arrayJoin},
+	} {
+		_, err := proto.DefineOwnPropertyP(method.name, types.NewDataPropDesc(
+			types.NewBuiltinfn(fnProto, method.fn), true, false, true,
+		), true)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return proto, nil
+}
+
+// NewArrayConstructor creates the Array constructor extending
+// fnProto, proto is the prototype of the arrays it creates. Calling
+// Array as a function is the same as using it with the new operator.
+// https://es5.github.io/#x15.4.1
+// https://es5.github.io/#x15.4.3
+func NewArrayConstructor(
+	proto *types.Array, fnProto types.Value,
+) (*types.BuiltinConstructor, error) {
+	construct := func(args []types.Value) (types.Value, error) {
+		return newArray(proto, args)
+	}
+
+	array := types.NewBuiltinConstructor(fnProto,
+		func(_ types.Object, args []types.Value) (types.Value, error) {
+			return construct(args)
+		},
+		construct,
+	)
+
+	_, err := array.DefineOwnPropertyP(prototypeAttr,
+		types.NewDataPropDesc(proto, false, false, false), true)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = array.DefineOwnPropertyP(isArrayAttr, types.NewDataPropDesc(
+		types.NewBuiltinfn(fnProto, arrayIsArray), true, false, true,
+	), true)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = proto.DefineOwnPropertyP(constructorAttr,
+		types.NewDataPropDesc(array, true, false, true), true)
+	if err != nil {
+		return nil, err
+	}
+
+	return array, nil
+}
+
+// newArray creates an array with the elements args or, if the only
+// argument is a number, with its length.
+// https://es5.github.io/#x15.4.2
+func newArray(proto *types.Array, args []types.Value) (types.Value, error) {
+	arr := types.NewArray(proto)
+
+	if len(args) == 1 && args[0].Kind() == types.KindNumber {
+		err := arr.Put(lengthAttr, args[0], true)
+		return arr, err
+	}
+
+	for i, arg := range args {
+		_, err := arr.DefineOwnPropertyP(
+			utf16.S(strconv.Itoa(i)),
+			types.NewDataPropDesc(arg, true, true, true),
+			true,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return arr, nil
+}
+
+// https://es5.github.io/#x15.4.3.2
+func arrayIsArray(_ types.Object, args []types.Value) (types.Value, error) {
+	if len(args) == 0 || args[0].Kind() != types.KindObject {
+		return types.False, nil
+	}
+
+	obj := args[0].(types.Object)
+	return types.NewBool(obj.Class() == "Array"), nil
+}
+
+// https://es5.github.io/#x15.4.4.2
+func arrayToString(this types.Object, _ []types.Value) (types.Value, error) {
+	if this == nil {
+		return objectToString(this, nil)
+	}
+
+	join, err := this.Get(joinAttr)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, ok := join.(types.Function)
+	if !ok {
+		return objectToString(this, nil)
+	}
+
+	return fn.Call(this, nil)
+}
+
+// https://es5.github.io/#x15.4.4.5
+func arrayJoin(this types.Object, args []types.Value) (types.Value, error) {
+	if this == nil {
+		return nil, types.NewTypeError("Array.prototype.join called on undefined")
+	}
+
+	lenVal, err := this.Get(lengthAttr)
+	if err != nil {
+		return nil, err
+	}
+
+	length := lenVal.ToNumber().ToUint32()
+
+	sep := ","
+	if len(args) > 0 && !types.StrictEqual(args[0], types.Undefined) {
+		sep = args[0].ToString().String()
+	}
+
+	elems := make([]string, length)
+	for i := range elems {
+		elem, err := this.Get(utf16.S(strconv.Itoa(i)))
+		if err != nil {
+			return nil, err
+		}
+
+		if types.StrictEqual(elem, types.Undefined) ||
+			types.StrictEqual(elem, types.Null) {
+			continue
+		}
+
+		elems[i] = elem.ToString().String()
+	}
+
+	return types.NewString(strings.Join(elems, sep)), nil
+}
@@ -0,0 +1,65 @@
+package builtins_test
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/abad/builtins"
+	"github.com/NeowayLabs/abad/internal/utf16"
+	"github.com/NeowayLabs/abad/types"
+	"github.com/madlambda/spells/assert"
+)
+
+func TestArrayPrototype(t *testing.T) {
+	objectProto, fnProto, err := builtins.NewObjectPrototype()
+	assert.NoError(t, err, "object prototype creation")
+
+	proto, err := builtins.NewArrayPrototype(objectProto, fnProto)
+	assert.NoError(t, err, "array prototype creation")
+
+	arr := types.NewArray(proto)
+	assert.EqualStrings(t, "", arr.String(), "empty array toString")
+
+	for i, val := range []types.Value{
+		types.NewNumber(1), types.Undefined, types.NewString("a"), types.Null,
+	} {
+		err := arr.Put(utf16.S(string('0'+rune(i))), val, true)
+		assert.NoError(t, err, "put %d", i)
+	}
+
+	assert.EqualStrings(t, "1,,a,", arr.String(), "array toString")
+
+	if len(arr.Enumerate()) != 4 {
+		t.Fatalf("prototype methods must not be enumerable: %v", arr.Enumerate())
+	}
+}
+
+func TestArrayIsArray(t *testing.T) {
+	objectProto, fnProto, err := builtins.NewObjectPrototype()
+	assert.NoError(t, err, "object prototype creation")
+
+	proto, err := builtins.NewArrayPrototype(objectProto, fnProto)
+	assert.NoError(t, err, "array prototype creation")
+
+	array, err := builtins.NewArrayConstructor(proto, fnProto)
+	assert.NoError(t, err, "array constructor creation")
+
+	isArray, err := array.Get(utf16.S("isArray"))
+	assert.NoError(t, err, "get isArray")
+
+	for _, tc := range []struct {
+		arg  types.Value
+		want types.Value
+	}{
+		{arg: types.NewArray(proto), want: types.True},
+		{arg: proto, want: types.True},
+		{arg: types.NewDataObject(proto), want: types.False},
+		{arg: types.NewString("a"), want: types.False},
+	} {
+		got, err := isArray.(types.Function).Call(array, []types.Value{tc.arg})
+		assert.NoError(t, err, "isArray(%s)", tc.arg)
+
+		if !types.StrictEqual(tc.want, got) {
+			t.Fatalf("isArray(%s): expected %s but got %s", tc.arg, tc.want, got)
+		}
+	}
+}
@@ -19,12 +19,14 @@ var (
 	toStringAttr = utf16.S("toString")
 )
 
-func NewConsole() (*Console, error) {
+// NewConsole creates the console object, its methods extend fnProto.
+func NewConsole(fnProto types.Value) (*Console, error) {
 	console := &Console{
 		DataObject: types.NewBaseDataObject(),
 	}
+	console.Embed(console)
 
-	logfn, err := newlog()
+	logfn, err := newlog(fnProto)
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +36,7 @@ func NewConsole() (*Console, error) {
 		return nil, err
 	}
 
-	toStrfn := types.NewBuiltinfn(
+	toStrfn := types.NewBuiltinfn(fnProto,
 		toStringer("[object Object]"),
 	)
 
@@ -43,21 +45,21 @@ func NewConsole() (*Console, error) {
 	return console, nil
 }
 
-func newlog() (*types.Builtinfn, error) {
-	logfn := types.NewBuiltinfn(log)
-	toStrfn := types.NewBuiltinfn(
+func newlog(fnProto types.Value) (*types.Builtinfn, error) {
+	logfn := types.NewBuiltinfn(fnProto, log)
+	toStrfn := types.NewBuiltinfn(fnProto,
 		toStringer("function () { [native code] }"),
 	)
 	err := logfn.Put(toStringAttr, toStrfn, true)
 	return logfn, err
 }
 
-func log(_ types.Object, args []types.Value) types.Value {
+func log(_ types.Object, args []types.Value) (types.Value, error) {
 	// This will not handle errors in formatting properly
 	// But it will work for well formatted messages
 	if len(args) == 0 {
 		fmt.Println("")
-		return types.Undefined
+		return types.Undefined, nil
 	}
 
 	vals := []string{}
@@ -71,7 +73,7 @@ func log(_ types.Object, args []types.Value) types.Value {
 		msg = strings.Join(vals, " ")
 	}
 	fmt.Println(msg)
-	return types.Undefined
+	return types.Undefined, nil
 }
 
 func sprintf(vals []string) string {
@@ -98,7 +100,7 @@ func hasFormatting(a string) bool {
 }
 
 func toStringer(str string) types.Execfn {
-	return func(_ types.Object, args []types.Value) types.Value {
-		return types.NewString(str)
+	return func(_ types.Object, args []types.Value) (types.Value, error) {
+		return types.NewString(str), nil
 	}
 }
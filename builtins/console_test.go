@@ -10,7 +10,7 @@ import (
 )
 
 func TestConsoleToString(t *testing.T) {
-	console, err := builtins.NewConsole()
+	console, err := builtins.NewConsole(types.Null)
 	assert.NoError(t, err, "console creation")
 	assert.EqualStrings(t, console.String(),
 		"[object Object]", "console toString")
@@ -0,0 +1,43 @@
+package builtins
+
+import (
+	"github.com/NeowayLabs/abad/ast"
+	"github.com/NeowayLabs/abad/internal/utf16"
+	"github.com/NeowayLabs/abad/types"
+)
+
+// initFunctionPrototype defines the methods of the Function
+// prototype object.
+// https://es5.github.io/#x15.3.4
+func initFunctionPrototype(proto *types.UserFunction) error {
+	_, err := proto.DefineOwnPropertyP(toStringAttr, types.NewDataPropDesc(
+		types.NewBuiltinfn(proto, functionToString), true, false, true,
+	), true)
+
+	return err
+}
+
+// functionToString returns the code of user functions. The
+// original source isn't kept, then it's printed from the syntax
+// tree. Built-in functions and the Function prototype itself
+// have no code at all.
+// https://es5.github.io/#x15.3.4.2
+func functionToString(this types.Object, _ []types.Value) (types.Value, error) {
+	if _, ok := this.(types.Function); !ok {
+		return nil, types.NewTypeError(
+			"Function.prototype.toString requires that 'this' be a Function")
+	}
+
+	fn, ok := this.(*types.UserFunction)
+	if !ok || fn.Body() == nil {
+		return types.NewString("function () { [native code] }"), nil
+	}
+
+	args := make([]ast.Ident, len(fn.Params()))
+	for i, param := range fn.Params() {
+		args[i] = ast.NewIdent(param)
+	}
+
+	code := ast.NewFunDecl(ast.NewIdent(utf16.S("")), args, fn.Body())
+	return types.NewString(code.String()), nil
+}
@@ -0,0 +1,48 @@
+package builtins_test
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/abad/builtins"
+	"github.com/NeowayLabs/abad/internal/utf16"
+	"github.com/NeowayLabs/abad/types"
+	"github.com/madlambda/spells/assert"
+)
+
+func TestFunctionPrototype(t *testing.T) {
+	objectProto, fnProto, err := builtins.NewObjectPrototype()
+	assert.NoError(t, err, "object prototype creation")
+	assert.EqualStrings(t, "Function", fnProto.Class(), "function prototype class")
+
+	if fnProto.Prototype() != types.Value(objectProto) {
+		t.Fatal("function prototype must extend the object prototype")
+	}
+
+	for _, name := range []string{"toString", "valueOf"} {
+		method, err := objectProto.Get(utf16.S(name))
+		assert.NoError(t, err, "get %s", name)
+
+		if method.(types.Object).Prototype() != types.Value(fnProto) {
+			t.Fatalf("%s must extend the function prototype", name)
+		}
+	}
+
+	got, err := fnProto.Call(nil, []types.Value{types.NewNumber(1)})
+	assert.NoError(t, err, "call function prototype")
+	if !types.StrictEqual(got, types.Undefined) {
+		t.Fatalf("expected undefined but got %s", got)
+	}
+
+	_, err = fnProto.Construct(nil)
+	assert.EqualErrs(t, types.NewTypeError(
+		"Function.prototype is not a constructor",
+	), err, "new function prototype")
+
+	str, err := fnProto.Get(utf16.S("toString"))
+	assert.NoError(t, err, "get toString")
+
+	got, err = str.(types.Function).Call(fnProto, nil)
+	assert.NoError(t, err, "function prototype toString")
+	assert.EqualStrings(t, "function () { [native code] }", got.ToString().String(),
+		"function prototype toString")
+}
@@ -0,0 +1,84 @@
+package builtins
+
+import (
+	"github.com/NeowayLabs/abad/internal/utf16"
+	"github.com/NeowayLabs/abad/types"
+)
+
+var (
+	valueOfAttr        = utf16.S("valueOf")
+	hasOwnPropertyAttr = utf16.S("hasOwnProperty")
+)
+
+// NewObjectPrototype creates the Object prototype object, the
+// prototype of the objects created by object literals, and the
+// Function prototype object that extends it. They are created
+// together because the methods of the Object prototype are
+// functions extending the Function prototype.
+// https://es5.github.io/#x15.2.4
+func NewObjectPrototype() (*types.DataObject, *types.UserFunction, error) {
+	proto := types.NewBaseDataObject()
+	fnProto := types.NewFunctionPrototype(proto)
+
+	for _, method := range []struct {
+		name utf16.Str
+		fn   types.Execfn
+	}{
+		{name: toStringAttr, fn: objectToString},
+		{name: valueOfAttr, fn: objectValueOf},
+		{name: hasOwnPropertyAttr, fn: objectHasOwnProperty},
+	} {
+		_, err := proto.DefineOwnPropertyP(method.name, types.NewDataPropDesc(
+			types.NewBuiltinfn(fnProto, method.fn), true, false, true,
+		), true)
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	err := initFunctionPrototype(fnProto)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return proto, fnProto, nil
+}
+
+// https://es5.github.io/#x15.2.4.2
+func objectToString(this types.Object, _ []types.Value) (types.Value, error) {
+	if this == nil {
+		return types.NewString("[object Undefined]"), nil
+	}
+
+	return types.NewString("[object " + this.Class() + "]"), nil
+}
+
+// https://es5.github.io/#x15.2.4.4
+func objectValueOf(this types.Object, _ []types.Value) (types.Value, error) {
+	if this == nil {
+		return nil, types.NewTypeError("Cannot convert undefined or null to object")
+	}
+
+	return this, nil
+}
+
+// https://es5.github.io/#x15.2.4.5
+func objectHasOwnProperty(this types.Object, args []types.Value) (types.Value, error) {
+	var name types.Value = types.Undefined
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	prim, err := name.ToPrimitive(types.KindString)
+	if err != nil {
+		return nil, err
+	}
+
+	if this == nil {
+		return nil, types.NewTypeError("Cannot convert undefined or null to object")
+	}
+
+	desc := this.GetOwnProperty(utf16.Str(prim.ToString()))
+	return types.NewBool(desc.Kind() != types.KindUndefined), nil
+}
@@ -0,0 +1,40 @@
+package builtins_test
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/abad/builtins"
+	"github.com/NeowayLabs/abad/internal/utf16"
+	"github.com/NeowayLabs/abad/types"
+	"github.com/madlambda/spells/assert"
+)
+
+func TestObjectPrototype(t *testing.T) {
+	proto, _, err := builtins.NewObjectPrototype()
+	assert.NoError(t, err, "object prototype creation")
+
+	obj := types.NewDataObject(proto)
+	assert.EqualStrings(t, "[object Object]", obj.String(), "object toString")
+
+	val, err := obj.ToPrimitive(types.KindNumber)
+	assert.NoError(t, err, "object to primitive")
+	assert.EqualStrings(t, "[object Object]", val.ToString().String(),
+		"valueOf must not return a primitive")
+
+	if len(obj.Enumerate()) != 0 {
+		t.Fatalf("prototype methods must not be enumerable: %v", obj.Enumerate())
+	}
+}
+
+func TestObjectValueOfDetached(t *testing.T) {
+	proto, _, err := builtins.NewObjectPrototype()
+	assert.NoError(t, err, "object prototype creation")
+
+	valueOf, err := proto.Get(utf16.S("valueOf"))
+	assert.NoError(t, err, "get valueOf")
+
+	_, err = valueOf.(types.Function).Call(nil, nil)
+	assert.EqualErrs(t, types.NewTypeError(
+		"Cannot convert undefined or null to object",
+	), err, "valueOf called on undefined")
+}
@@ -30,7 +30,7 @@ func TestCli(t *testing.T) {
 		},
 		{
 			in:  "1e-10",
-			out: "0.0000000001",
+			out: "1e-10",
 		},
 		{
 			in:  "1e10",
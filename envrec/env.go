@@ -7,6 +7,8 @@ import (
 	"github.com/NeowayLabs/abad/types"
 )
 
+var _ types.Bindings = &Decl{}
+
 type (
 	// Env is an environment record storage
 	Env interface {
@@ -30,6 +32,14 @@ type (
 	Decl struct {
 		records map[string]Record
 	}
+
+	// Object environment record, the bindings are the
+	// properties of the binding object.
+	// https://es5.github.io/#x10.2.1.2
+	Object struct {
+		bindings    types.Object
+		provideThis bool
+	}
 )
 
 func NewDeclEnv() *Decl {
@@ -52,6 +62,35 @@ func (env *Decl) New(name utf16.Str, candelete bool) error {
 	return nil
 }
 
+// NewImmutable creates an uninitialized immutable binding,
+// its value must be set with InitImmutable.
+// https://es5.github.io/#x10.2.1.1.7
+func (env *Decl) NewImmutable(name utf16.Str) error {
+	if len(name) == 0 {
+		return fmt.Errorf("empty binding name")
+	}
+
+	env.records[name.String()] = Record{
+		mutable: false,
+		value:   types.Undefined,
+	}
+
+	return nil
+}
+
+// InitImmutable sets the value of the immutable binding name.
+// https://es5.github.io/#x10.2.1.1.8
+func (env *Decl) InitImmutable(name utf16.Str, v types.Value) error {
+	r, ok := env.records[name.String()]
+	if !ok || r.mutable {
+		return fmt.Errorf("%s is not an immutable binding", name)
+	}
+
+	r.value = v
+	env.records[name.String()] = r
+	return nil
+}
+
 func (env *Decl) Has(name utf16.Str) bool {
 	_, ok := env.records[name.String()]
 	return ok
@@ -60,7 +99,7 @@ func (env *Decl) Has(name utf16.Str) bool {
 func (env *Decl) Set(name utf16.Str, v types.Value, musterr bool) error {
 	if !env.Has(name) {
 		if musterr {
-			return fmt.Errorf("%s is not defined", name)
+			return types.NewReferenceError("%s is not defined", name)
 		}
 
 		env.New(name, true)
@@ -68,6 +107,15 @@ func (env *Decl) Set(name utf16.Str, v types.Value, musterr bool) error {
 
 	str := name.String()
 	r := env.records[str]
+	if !r.mutable {
+		// https://es5.github.io/#x10.2.1.1.3
+		if musterr {
+			return types.NewTypeError("Assignment to constant variable %s", name)
+		}
+
+		return nil
+	}
+
 	r.value = v
 	env.records[str] = r
 	return nil
@@ -77,7 +125,7 @@ func (env *Decl) Get(name utf16.Str, musterr bool) (types.Value, error) {
 	r, ok := env.records[name.String()]
 	if !ok {
 		if musterr {
-			return nil, fmt.Errorf("%s is not defined", name)
+			return nil, types.NewReferenceError("%s is not defined", name)
 		}
 
 		return types.Undefined, nil
@@ -103,3 +151,56 @@ func (env *Decl) Del(name utf16.Str) bool {
 func (env *Decl) ImplicitThis() types.Value {
 	return nil
 }
+
+// NewObjectEnv creates an environment record for the properties
+// of bindings. If provideThis is true then the binding object is
+// the implicit this value of function calls.
+func NewObjectEnv(bindings types.Object, provideThis bool) *Object {
+	return &Object{
+		bindings:    bindings,
+		provideThis: provideThis,
+	}
+}
+
+func (env *Object) New(name utf16.Str, candelete bool) error {
+	if len(name) == 0 {
+		return fmt.Errorf("empty binding name")
+	}
+
+	desc := types.NewDataPropDesc(types.Undefined, true, true, candelete)
+	_, err := env.bindings.DefineOwnProperty(name, desc.ToObject(), true)
+	return err
+}
+
+func (env *Object) Has(name utf16.Str) bool {
+	return env.bindings.HasProperty(name)
+}
+
+func (env *Object) Set(name utf16.Str, v types.Value, musterr bool) error {
+	return env.bindings.Put(name, v, musterr)
+}
+
+func (env *Object) Get(name utf16.Str, musterr bool) (types.Value, error) {
+	if !env.Has(name) {
+		if musterr {
+			return nil, types.NewReferenceError("%s is not defined", name)
+		}
+
+		return types.Undefined, nil
+	}
+
+	return env.bindings.Get(name)
+}
+
+func (env *Object) Del(name utf16.Str) bool {
+	ok, _ := env.bindings.Delete(name, false)
+	return ok
+}
+
+func (env *Object) ImplicitThis() types.Value {
+	if env.provideThis {
+		return env.bindings
+	}
+
+	return types.Undefined
+}
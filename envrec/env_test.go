@@ -19,36 +19,78 @@ type testcase struct {
 var S = utf16.S
 var E = fmt.Errorf
 
+var envTestcases = []testcase{
+	{
+		ident: "console",
+		value: types.NewNumber(1),
+	},
+	{
+		ident: "window",
+		value: types.NewNumber(666.0),
+	},
+	{
+		ident: "",
+		err:   E("empty binding name"),
+	},
+	{
+		ident: "_",
+		value: types.Undefined,
+	},
+	{
+		ident: "$",
+		value: types.NewString("jquery"),
+	},
+}
+
 func TestEnvDecl(t *testing.T) {
-	for _, tc := range []testcase{
-		{
-			ident: "console",
-			value: types.NewNumber(1),
-		},
-		{
-			ident: "window",
-			value: types.NewNumber(666.0),
-		},
-		{
-			ident: "",
-			err:   E("empty binding name"),
-		},
-		{
-			ident: "_",
-			value: types.Undefined,
-		},
-		{
-			ident: "$",
-			value: types.NewString("jquery"),
-		},
-	} {
-		testEnvRecDecl(t, tc)
+	for _, tc := range envTestcases {
+		testEnvRec(t, envrec.NewDeclEnv(), tc)
+	}
+}
+
+func TestEnvObject(t *testing.T) {
+	for _, tc := range envTestcases {
+		obj := types.NewBaseDataObject()
+		testEnvRec(t, envrec.NewObjectEnv(obj, false), tc)
+	}
+}
+
+func TestEnvObjectBindsProperties(t *testing.T) {
+	obj := types.NewBaseDataObject()
+	env := envrec.NewObjectEnv(obj, false)
+	name := S("a")
+
+	err := env.New(name, false)
+	assert.NoError(t, err, "failed to create binding")
+
+	err = env.Set(name, types.NewNumber(1), true)
+	assert.NoError(t, err, "failed to set binding")
+
+	got, err := obj.Get(name)
+	assert.NoError(t, err, "failed to get property")
+
+	if !types.StrictEqual(got, types.NewNumber(1)) {
+		t.Fatalf("binding is not a property: got '%s'", got)
+	}
+
+	if env.Del(name) {
+		t.Fatalf("deleted a binding created as not deletable")
+	}
+
+	_, err = env.Get(S("b"), true)
+	assert.EqualErrs(t, types.NewReferenceError("b is not defined"), err,
+		"unexpected error")
+
+	got, err = env.Get(S("b"), false)
+	assert.NoError(t, err, "non strict get must not fail")
+
+	if !types.StrictEqual(got, types.Undefined) {
+		t.Fatalf("expected undefined but got '%s'", got)
 	}
 }
 
-func testEnvRecDecl(t *testing.T, tc testcase) {
+func testEnvRec(t *testing.T, env envrec.Env, tc testcase) {
 	ident := S(tc.ident)
-	env := envrec.NewDeclEnv()
 	err := env.New(ident, true)
 	assert.EqualErrs(t, tc.err, err, "errs dont match")
 
@@ -79,3 +121,31 @@ func testEnvRecDecl(t *testing.T, tc testcase) {
 		t.Fatalf("DeclEnv still have a deleted binding")
 	}
 }
+
+func TestEnvDeclImmutable(t *testing.T) {
+	env := envrec.NewDeclEnv()
+	name := S("f")
+
+	err := env.NewImmutable(name)
+	assert.NoError(t, err, "failed to create immutable binding")
+
+	err = env.InitImmutable(name, types.NewNumber(1))
+	assert.NoError(t, err, "failed to initialize immutable binding")
+
+	err = env.Set(name, types.NewNumber(2), false)
+	assert.NoError(t, err, "non strict set must fail silently")
+
+	err = env.Set(name, types.NewNumber(2), true)
+	assert.EqualErrs(t, types.NewTypeError("Assignment to constant variable f"),
+		err, "strict set must fail")
+
+	got, err := env.Get(name, true)
+	assert.NoError(t, err, "failed to get immutable binding")
+
+	if !types.StrictEqual(got, types.NewNumber(1)) {
+		t.Fatalf("immutable binding changed to '%s'", got)
+	}
+
+	err = env.InitImmutable(S("g"), types.NewNumber(1))
+	assert.Error(t, err, "initialized an unknown binding")
+}
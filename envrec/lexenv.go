@@ -0,0 +1,57 @@
+package envrec
+
+import (
+	"github.com/NeowayLabs/abad/internal/utf16"
+	"github.com/NeowayLabs/abad/types"
+)
+
+type (
+	// LexEnv is a lexical environment, an environment record
+	// and a reference to the outer lexical environment (nil for
+	// the global environment).
+	// https://es5.github.io/#x10.2
+	LexEnv struct {
+		rec   Env
+		outer *LexEnv
+	}
+)
+
+// NewDeclLexEnv creates a lexical environment with an empty
+// declarative environment record.
+// https://es5.github.io/#x10.2.2.2
+func NewDeclLexEnv(outer *LexEnv) *LexEnv {
+	return &LexEnv{
+		rec:   NewDeclEnv(),
+		outer: outer,
+	}
+}
+
+// NewObjectLexEnv creates a lexical environment whose bindings are
+// the properties of obj.
+// https://es5.github.io/#x10.2.2.3
+func NewObjectLexEnv(obj types.Object, outer *LexEnv, provideThis bool) *LexEnv {
+	return &LexEnv{
+		rec:   NewObjectEnv(obj, provideThis),
+		outer: outer,
+	}
+}
+
+// Rec returns the environment record of lex.
+func (lex *LexEnv) Rec() Env { return lex.rec }
+
+// Outer returns the outer lexical environment, nil if lex is
+// the global environment.
+func (lex *LexEnv) Outer() *LexEnv { return lex.outer }
+
+// GetIdentifierReference walks the environment chain looking for the
+// record that binds name. It returns nil if name is not resolvable.
+// https://es5.github.io/#x10.2.2.1
+func (lex *LexEnv) GetIdentifierReference(name utf16.Str) Env {
+	for env := lex; env != nil; env = env.outer {
+		if env.rec.Has(name) {
+			return env.rec
+		}
+	}
+
+	return nil
+}
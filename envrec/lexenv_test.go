@@ -0,0 +1,70 @@
+package envrec_test
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/abad/envrec"
+	"github.com/NeowayLabs/abad/types"
+	"github.com/madlambda/spells/assert"
+)
+
+func TestLexEnvChain(t *testing.T) {
+	global := types.NewBaseDataObject()
+	globalEnv := envrec.NewObjectLexEnv(global, nil, false)
+	outer := envrec.NewDeclLexEnv(globalEnv)
+	inner := envrec.NewDeclLexEnv(outer)
+
+	if inner.Outer() != outer || outer.Outer() != globalEnv {
+		t.Fatalf("outer references are wrong")
+	}
+
+	if globalEnv.Outer() != nil {
+		t.Fatalf("global environment must not have an outer environment")
+	}
+
+	bind := func(env *envrec.LexEnv, name string, val types.Value) {
+		err := env.Rec().New(S(name), false)
+		assert.NoError(t, err, "failed to create binding %s", name)
+
+		err = env.Rec().Set(S(name), val, true)
+		assert.NoError(t, err, "failed to set binding %s", name)
+	}
+
+	bind(globalEnv, "a", types.NewNumber(1))
+	bind(globalEnv, "b", types.NewNumber(2))
+	bind(outer, "b", types.NewNumber(3)) // shadows global b
+	bind(inner, "c", types.NewNumber(4))
+
+	for _, tc := range []struct {
+		name string
+		want *envrec.LexEnv
+	}{
+		{name: "a", want: globalEnv},
+		{name: "b", want: outer},
+		{name: "c", want: inner},
+		{name: "d"},
+	} {
+		got := inner.GetIdentifierReference(S(tc.name))
+		if tc.want == nil {
+			if got != nil {
+				t.Fatalf("%s must not be resolvable", tc.name)
+			}
+			continue
+		}
+
+		if got != tc.want.Rec() {
+			t.Fatalf("%s resolved to the wrong environment", tc.name)
+		}
+	}
+
+	if outer.GetIdentifierReference(S("c")) != nil {
+		t.Fatalf("inner bindings must not be visible from outer environment")
+	}
+
+	val, err := globalEnv.Rec().Get(S("b"), true)
+	assert.NoError(t, err, "failed to get global b")
+
+	if !types.StrictEqual(val, types.NewNumber(2)) {
+		t.Fatalf("global b changed to %s", val)
+	}
+}
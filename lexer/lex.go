@@ -241,6 +241,14 @@ func (l *lexer) dotState() (Tokval, lexerState) {
 	if l.isTokenEnd() {
 		return l.illegalToken()
 	}
+
+	if !l.isNumber() {
+		// member access on expressions other than identifiers.
+		// eg.: a().b
+		l.bwd()
+		return l.token(token.Dot), l.startIdentifierState
+	}
+
 	allowExponent := true
 	allowDot := false
 	return l.decimalState(allowExponent, allowDot)
@@ -312,7 +320,7 @@ func (l *lexer) identifierState() (Tokval, lexerState) {
 
 		if l.isDot() {
 			l.bwd()
-			return l.token(token.Ident), l.accessMemberState
+			return l.identOrKeywordToken(), l.accessMemberState
 		}
 
 		if l.isLeftParen() || l.isTokenEnd() {
@@ -345,7 +353,7 @@ func (l *lexer) startIdentifierState() (Tokval, lexerState) {
 		return l.illegalToken()
 	}
 
-	if l.isDot() {
+	if l.isDot() || l.isTokenEnd() {
 		return l.illegalToken()
 	}
 
@@ -408,13 +416,12 @@ func (l *lexer) decimalState(allowExponent bool, allowDot bool) (Tokval, lexerSt
 
 func (l *lexer) exponentPartState() (Tokval, lexerState) {
 
-	if l.isTokenEnd() {
-		return l.illegalToken()
+	if !l.isEOF() && (l.isMinusSign() || l.isPlusSign()) {
+		l.fwd()
 	}
 
-	if l.isMinusSign() || l.isPlusSign() {
-		// TODO: test 1.0e- and 1.0e+
-		l.fwd()
+	if l.isTokenEnd() {
+		return l.illegalToken()
 	}
 
 	allowExponent := false
@@ -469,10 +476,6 @@ func (l *lexer) isLeftParen() bool {
 	return l.cur() == leftParen
 }
 
-func (l *lexer) isRightParen() bool {
-	return l.cur() == rightParen
-}
-
 func (l *lexer) isNewline() bool {
 	if l.isEOF() {
 		return false
@@ -495,24 +498,19 @@ func (l *lexer) isExponentPartStart() bool {
 	return containsRune(exponentPartStart, l.cur())
 }
 
-func (l *lexer) isComma() bool {
-	return l.cur() == comma
-}
-
 func (l *lexer) isDoubleQuote() bool {
 	return l.cur() == doubleQuote
 }
 
-func (l *lexer) isSemiColon() bool {
-	return l.cur() == semiColon
-}
-
-// tokenEnd tries to capture the most common causes of a token ending
+// tokenEnd tries to capture the most common causes of a token ending.
+// Any punctuator (except the dot, that is part of numbers) ends the
+// current token, eg.: a+b, 1*2, a[0]
 func (l *lexer) isTokenEnd() bool {
 	if l.isEOF() {
 		return true
 	}
-	return l.isRightParen() || l.isComma() || l.isNewline() || l.isSemiColon() || l.isWhiteSpace()
+	return l.isNewline() || l.isWhiteSpace() ||
+		(l.isPunctuator() && !l.isDot())
 }
 
 func (l *lexer) fwd() {
@@ -629,6 +627,7 @@ func newKeywords() map[string]token.Type {
 		"true":       token.Bool,
 		"break":      token.Break,
 		"case":       token.Case,
+		"catch":      token.Catch,
 		"continue":   token.Continue,
 		"debugger":   token.Debugger,
 		"default":    token.Default,
@@ -304,6 +304,11 @@ func TestKeywords(t *testing.T) {
 			code: Str("case"),
 			want: keyword(token.Case, "case"),
 		},
+		{
+			name: "Catch",
+			code: Str("catch"),
+			want: keyword(token.Catch, "catch"),
+		},
 		{
 			name: "Continue",
 			code: Str("continue"),
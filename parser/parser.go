@@ -8,6 +8,7 @@ import (
 	"github.com/NeowayLabs/abad/internal/utf16"
 	"github.com/NeowayLabs/abad/lexer"
 	"github.com/NeowayLabs/abad/token"
+	"github.com/NeowayLabs/abad/types"
 )
 
 type (
@@ -15,9 +16,45 @@ type (
 		tokens    <-chan lexer.Tokval
 		lookahead []lexer.Tokval
 
+		// last consumed token, required by the
+		// automatic semicolon insertion rules.
+		prev lexer.Tokval
+
 		filename string
 
-		openbraces int
+		// tells if parsing a function body, return
+		// statements are not allowed elsewhere.
+		infunc bool
+
+		// tells if parsing a loop body, required to
+		// validate break and continue statements.
+		inloop bool
+
+		// tells if parsing the clauses of a switch,
+		// where break statements are allowed.
+		inswitch bool
+
+		// tells if parsing strict mode code.
+		strict bool
+
+		// tells if the in operator is not allowed, as in the
+		// init expression of for statements where it would be
+		// ambiguous with the for-in statement.
+		// http://es5.github.io/#x12.6
+		noIn bool
+
+		// labels of the statements enclosing the one being
+		// parsed, required to validate break and continue
+		// targets.
+		labels []label
+	}
+
+	label struct {
+		name string
+
+		// tells if it labels an iteration statement,
+		// the only ones that can be continued.
+		loop bool
 	}
 
 	parserfn func(*Parser) (ast.Node, error)
@@ -27,11 +64,38 @@ type (
 var tokEOF = lexer.EOF
 
 var (
-	keywordParsers   map[token.Type]parserfn
-	literalParsers   map[token.Type]parserfn
-	unaryParsers     map[token.Type]parserfn
-	varAssignParsers map[token.Type]parserfn
-	nodeParsers      map[token.Type]parserfn
+	keywordParsers map[token.Type]parserfn
+	literalParsers map[token.Type]parserfn
+	nodeParsers    map[token.Type]parserfn
+
+	// binary operators precedence, the higher the value
+	// the tighter the operator binds.
+	// http://es5.github.io/#x11.5 until http://es5.github.io/#x11.11
+	binaryPrecedence = map[token.Type]int{
+		token.Mul:        10,
+		token.Quo:        10,
+		token.Rem:        10,
+		token.Plus:       9,
+		token.Minus:      9,
+		token.LShift:     8,
+		token.RShift:     8,
+		token.RShiftZero: 8,
+		token.Less:       7,
+		token.Greater:    7,
+		token.LessEq:     7,
+		token.GreaterEq:  7,
+		token.InstanceOf: 7,
+		token.In:         7,
+		token.Equal:      6,
+		token.NotEqual:   6,
+		token.TEqual:     6,
+		token.NotTEqual:  6,
+		token.And:        5,
+		token.Xor:        4,
+		token.Or:         3,
+		token.LAnd:       2,
+		token.LOr:        1,
+	}
 )
 
 func init() {
@@ -39,11 +103,6 @@ func init() {
 		token.Function: parseFundecl,
 	}
 
-	unaryParsers = map[token.Type]parserfn{
-		token.Minus: parseUnary,
-		token.Plus:  parseUnary,
-	}
-
 	literalParsers = map[token.Type]parserfn{
 		token.Decimal:     parseDecimal,
 		token.Hexadecimal: parseHex,
@@ -53,20 +112,21 @@ func init() {
 		token.Null:        parseNull,
 	}
 
-	varAssignParsers = mergeParsers(
-		literalParsers,
-		map[token.Type]parserfn{
-			token.Ident: parseIdentExpr,
-		},
-	)
-
 	nodeParsers = mergeParsers(
 		keywordParsers,
-		literalParsers,
-		unaryParsers,
 		map[token.Type]parserfn{
-			token.Ident: parseIdentExpr,
-			token.Var:   parseVarDecls,
+			token.Var:      parseVarDecls,
+			token.Return:   parseReturnStmt,
+			token.If:       parseIfStmt,
+			token.While:    parseWhileStmt,
+			token.Do:       parseDoWhileStmt,
+			token.For:      parseForStmt,
+			token.Switch:   parseSwitchStmt,
+			token.Throw:    parseThrowStmt,
+			token.Try:      parseTryStmt,
+			token.Break:    parseBreakStmt,
+			token.Continue: parseContinueStmt,
+			token.With:     parseWithStmt,
 		},
 	)
 }
@@ -82,86 +142,109 @@ func Parse(fname string, code string) (*ast.Program, error) {
 }
 
 func (p *Parser) parse() (*ast.Program, error) {
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := p.parseStmts(token.EOF)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.Program{
+		Nodes:  append(directives, nodes...),
+		Strict: p.strict,
+	}, nil
+}
+
+// parseDirectives parses the directive prologue, the sequence of
+// string literal statements at the beginning of a program or function
+// body. The code is strict mode code if one of them is "use strict".
+// http://es5.github.io/#x14.1
+func (p *Parser) parseDirectives() ([]ast.Node, error) {
 	var nodes []ast.Node
 
-	for {
-		node, eof, err := p.parseNode()
+	for p.peek().Type == token.String {
+		node, err := p.parseStmt()
 		if err != nil {
 			return nil, err
 		}
 
-		if eof {
+		nodes = append(nodes, node)
+
+		// the string is part of an expression, eg.: "a" + b
+		if node.Type() != ast.NodeString {
 			break
 		}
 
-		nodes = append(nodes, node)
+		if utf16.Str(node.(ast.String)).String() == "use strict" {
+			p.strict = true
+		}
 	}
 
-	return &ast.Program{
-		Nodes: nodes,
-	}, nil
+	return nodes, nil
 }
 
-func (p *Parser) parseNode() (n ast.Node, eof bool, err error) {
-	p.scry(1)
-	tok := p.lookahead[0]
+// parseStmts parses statements until the end token is found.
+// The end token is not consumed.
+func (p *Parser) parseStmts(end token.Type) ([]ast.Node, error) {
+	var nodes []ast.Node
 
-	// http://es5.github.io/#A.4
-	if tok.Type == token.LBrace {
-		p.openbraces++
-		p.forget(1)
-		return p.parseNode()
-	}
+	for {
+		tok := p.peek()
+		if tok.Type == end {
+			return nodes, nil
+		}
 
-	if tok.Type == token.RBrace {
-		if p.openbraces <= 0 {
-			return nil, false, p.errorf(tok, "unexpected '}'")
+		switch tok.Type {
+		case token.EOF:
+			return nil, p.errorf(tok, "unexpected EOF")
+		case token.RBrace:
+			return nil, p.errorf(tok, "unexpected '}'")
 		}
 
-		p.openbraces--
-		p.forget(1)
-		return nil, true, nil
-	}
+		node, err := p.parseStmt()
+		if err != nil {
+			return nil, err
+		}
 
-	// FIXME: This will probably not be enough to handle semicolon on the future
-	for tok.Type == token.SemiColon {
-		p.forget(1)
-		p.scry(1)
-		tok = p.lookahead[0]
+		if node != nil {
+			nodes = append(nodes, node)
+		}
 	}
+}
 
-	if tok.Type == token.EOF {
-		return nil, true, nil
+// parseStmt parses a statement. Empty statements
+// are discarded, returning a nil node.
+// http://es5.github.io/#x12
+func (p *Parser) parseStmt() (ast.Node, error) {
+	tok := p.peek()
+
+	switch tok.Type {
+	case token.SemiColon:
+		p.forget(1)
+		return nil, nil
+	case token.LBrace:
+		return parseBlockStmt(p)
+	case token.Illegal:
+		return parseIllegal(p)
 	}
 
-	if tok.Type == token.Illegal {
-		_, err := parseIllegal(p)
-		return nil, false, err
+	if p.atLabel() {
+		return parseLabeledStmt(p)
 	}
 
 	parser, ok := nodeParsers[tok.Type]
-
 	if !ok {
-		return nil, false, p.errorf(tok, "invalid token: %s", tok)
-	}
-
-	node, err := parser(p)
-	if err != nil {
-		return nil, false, err
+		parser = parseExprStmt
 	}
 
-	// parsers should not leave tokens not processed
-	// in the lookahead buffer.
-	if len(p.lookahead) != 0 {
-		panic(fmt.Sprintf("parser for token[%v] not handled lookahead correctly, lookahead has[%v] but should be empty",
-			tok,
-			p.lookahead))
-	}
-	return node, false, nil
+	return parser(p)
 }
 
-// next token
-func (p *Parser) next() lexer.Tokval {
+// read the next token from the lexer
+func (p *Parser) read() lexer.Tokval {
 	tok, ok := <-p.tokens
 	if !ok {
 		return tokEOF
@@ -169,6 +252,26 @@ func (p *Parser) next() lexer.Tokval {
 	return tok
 }
 
+// next consumes the next token
+func (p *Parser) next() lexer.Tokval {
+	if len(p.lookahead) > 0 {
+		tok := p.lookahead[0]
+		p.forget(1)
+		return tok
+	}
+
+	p.prev = p.read()
+	return p.prev
+}
+
+// peek returns the next token without consuming it.
+func (p *Parser) peek() lexer.Tokval {
+	if len(p.lookahead) == 0 {
+		p.scry(1)
+	}
+	return p.lookahead[0]
+}
+
 // scry foretell the future using a crystal ball. Amount is how much
 // of the future you want to foresee.
 //
@@ -180,7 +283,7 @@ func (p *Parser) scry(amount int) bool {
 
 	got := 0
 	for i := 0; i < amount; i++ {
-		val := p.next()
+		val := p.read()
 		p.lookahead = append(p.lookahead, val)
 		got += 1
 		if val.Type == token.EOF {
@@ -193,9 +296,49 @@ func (p *Parser) scry(amount int) bool {
 
 // forget what you had foresee
 func (p *Parser) forget(amount int) {
+	p.prev = p.lookahead[amount-1]
 	p.lookahead = p.lookahead[amount:]
 }
 
+// expect consumes the next token, failing if it's not of type t.
+func (p *Parser) expect(t token.Type) (lexer.Tokval, error) {
+	tok := p.next()
+	if tok.Type == token.Illegal {
+		return tok, p.errorf(tok, "invalid token: %s", tok.Value)
+	}
+
+	if tok.Type != t {
+		return tok, p.errorf(tok, "unexpected %s, expected %s",
+			tok.Value, t)
+	}
+
+	return tok, nil
+}
+
+// endStmt checks if the statement is correctly terminated, applying
+// the automatic semicolon insertion rules when required.
+// http://es5.github.io/#x7.9
+func (p *Parser) endStmt() error {
+	tok := p.peek()
+
+	switch tok.Type {
+	case token.SemiColon:
+		p.forget(1)
+		return nil
+	case token.RBrace, token.EOF:
+		return nil
+	case token.Illegal:
+		_, err := parseIllegal(p)
+		return err
+	}
+
+	if tok.Line > p.prev.Line {
+		return nil
+	}
+
+	return p.errorf(tok, "unexpected %s", tok.Value)
+}
+
 func parseIllegal(p *Parser) (ast.Node, error) {
 	tok := p.lookahead[0]
 	return nil, p.errorf(tok, "invalid token: %s",
@@ -234,7 +377,7 @@ func parseDecimal(p *Parser) (ast.Node, error) {
 	decstr := tok.Value
 	f, err := strconv.ParseFloat(decstr.String(), 64)
 	if err != nil {
-		return nil, p.errorf(tok, err.Error())
+		return nil, p.errorf(tok, "%s", err)
 	}
 	return ast.NewNumber(f), nil
 }
@@ -248,287 +391,1505 @@ func parseHex(p *Parser) (ast.Node, error) {
 	hexstr = hexstr.TrimPrefix(hexPrefix)
 	hex, err := strconv.ParseInt(hexstr.String(), 16, 64)
 	if err != nil {
-		return nil, p.errorf(tok, err.Error())
+		return nil, p.errorf(tok, "%s", err)
 	}
 
 	return ast.NewIntNumber(hex), nil
 }
 
-func parseUnary(p *Parser) (ast.Node, error) {
-	tok := p.lookahead[0]
-	if !token.IsUnaryOperator(tok.Type) {
-		return nil, p.errorf(tok, "unexpected: %s", tok.Type)
-	}
+func parseVarDecls(p *Parser) (ast.Node, error) {
 	p.forget(1)
-	expr, eof, err := p.parseNode()
+
+	decls, err := parseVarDeclList(p)
 	if err != nil {
 		return nil, err
 	}
 
-	if eof {
-		return nil, p.errorf(tok, "unexpected eof")
-	}
-
-	if !ast.IsExpr(expr) {
-		return nil, p.errorf(tok, "expected expression, but got %s",
-			expr.Type())
-	}
-
-	return ast.NewUnaryExpr(tok.Type, expr), nil
-}
-
-func parseVarDecls(p *Parser) (ast.Node, error) {
-	p.forget(1)
-	return parseVarDeclList(p)
+	return decls, p.endStmt()
 }
 
 func parseVarDeclList(p *Parser) (ast.VarDecls, error) {
+	var decls ast.VarDecls
 
-	identifier := p.next()
-	if identifier.Type != token.Ident {
-		return nil, fmt.Errorf("parser: var decl: expected identifier got[%s]", identifier)
-	}
+	for {
+		identifier := p.next()
+		if identifier.Type != token.Ident {
+			return nil, p.errorf(identifier, "var decl: expected identifier got[%s]", identifier.Value)
+		}
+
+		varname := ast.NewIdent(identifier.Value)
+		if err := p.checkStrictBinding(identifier, varname); err != nil {
+			return nil, err
+		}
 
-	varname := ast.NewIdent(identifier.Value)
-	possibleAssignment := p.next()
+		tok := p.peek()
+		if tok.Type == token.Assign {
+			p.forget(1)
 
-	if possibleAssignment.Type == token.SemiColon {
-		return ast.NewVarDecls(ast.NewVarDecl(varname, ast.NewUndefined())), nil
-	}
+			val, err := parseAssignExpr(p)
+			if err != nil {
+				return nil, err
+			}
 
-	if possibleAssignment.Type != token.Assign {
-		return nil, fmt.Errorf("parser: var decl: expected assignment token [=] got [%s]", possibleAssignment)
-	}
+			decls = append(decls, ast.NewVarDecl(varname, val))
+		} else {
+			if tok.Type == token.EOF {
+				return nil, p.errorf(tok, "var decl: unexpected EOF")
+			}
 
-	p.scry(1)
-	assignExpr := p.lookahead[0]
-	parser, hasparser := varAssignParsers[assignExpr.Type]
+			decls = append(decls, ast.NewVarDecl(varname, nil))
+		}
 
-	if !hasparser {
-		return nil, fmt.Errorf("parser: var decl: invalid token[%s] expected assigment expression", assignExpr)
-	}
+		if p.peek().Type != token.Comma {
+			break
+		}
 
-	val, err := parser(p)
-	if err != nil {
-		return nil, fmt.Errorf("parser: var decl: error[%s] parsing variable assign expression", err)
+		p.forget(1)
 	}
 
-	res := ast.NewVarDecls(ast.NewVarDecl(varname, val))
-	possibleSemiColon := p.next()
+	return decls, nil
+}
 
-	if possibleSemiColon.Type == token.SemiColon || possibleSemiColon.Type == token.EOF {
-		return res, nil
+// http://es5.github.io/#x12.9
+func parseReturnStmt(p *Parser) (ast.Node, error) {
+	tok := p.next()
+	if !p.infunc {
+		return nil, p.errorf(tok, "illegal return statement")
 	}
 
-	if possibleSemiColon.Type != token.Comma {
-		return nil, fmt.Errorf("parser: var decl: invalid token[%s] expected comma", possibleSemiColon)
+	// no line terminator is allowed between return and its expression
+	next := p.peek()
+	switch {
+	case next.Type == token.SemiColon,
+		next.Type == token.RBrace,
+		next.Type == token.EOF,
+		next.Line > tok.Line:
+		return ast.NewReturnStmt(nil), p.endStmt()
 	}
 
-	vars, err := parseVarDeclList(p)
+	val, err := parseExpr(p)
 	if err != nil {
 		return nil, err
 	}
-	return append(res, vars...), nil
-}
-
-func parseIdentExpr(p *Parser) (ast.Node, error) {
-	tok := p.lookahead[0]
-	p.scry(1)
-	next := p.lookahead[1]
 
-	// eg.: console.
-	if next.Type == token.Dot {
-		p.forget(1)
-		return parseMemberExpr(p, ast.NewIdent(tok.Value))
-	}
+	return ast.NewReturnStmt(val), p.endStmt()
+}
 
-	// eg.: console(
-	if next.Type == token.LParen {
-		return parseCallExpr(p)
-	}
+// state:
+// lookahead[0] = token.LBrace
+// http://es5.github.io/#x12.1
+func parseBlockStmt(p *Parser) (ast.Node, error) {
+	p.forget(1)
 
-	if next.Type != token.EOF && next.Type != token.SemiColon {
-		return nil, p.errorf(next, "parser:identifier:unexpected token [%s]", next)
+	nodes, err := p.parseStmts(token.RBrace)
+	if err != nil {
+		return nil, err
 	}
 
-	p.forget(2)
+	p.forget(1) // drops }
 
-	return ast.NewIdent(tok.Value), nil
+	return ast.NewBlockStmt(nodes), nil
 }
 
-// state:
-// lookahead[0] = token.Dot
-func parseMemberExpr(p *Parser, object ast.Node) (ast.Node, error) {
+// http://es5.github.io/#x12.5
+func parseIfStmt(p *Parser) (ast.Node, error) {
 	p.forget(1)
 
-	tok := p.next()
-	if tok.Type != token.Ident {
-		return nil, p.errorf(tok, "unexpected %s", tok.Value)
+	cond, err := parseCondition(p)
+	if err != nil {
+		return nil, err
 	}
 
-	member := ast.NewMemberExpr(object, ast.NewIdent(tok.Value))
-
-	// TODO(i4k): Discuss this!
-	// We can just return the MemberExpr here but then if next token is '('
-	// then the main parser loop will need to retrieve the last parsed node
-	// to use it as the object in the CallExpr.
-	// Going into the CallExpr parser from here avoid to keep some states
-	// in the parser structure but can lead to some code duplicates also.
-	// I'm not sure of what's the best approach yet... Coding the naive one
-	// for now.
-	p.scry(1)
-
-	tok = p.lookahead[0]
-	if tok.Type == token.LParen {
-		return parseMemberFuncall(p, member)
+	then, err := parseSubStmt(p)
+	if err != nil {
+		return nil, err
 	}
 
-	if tok.Type == token.Dot {
-		return parseMemberExpr(p, member)
+	if p.peek().Type != token.Else {
+		return ast.NewIfStmt(cond, then, nil), nil
 	}
 
-	if tok.Type != token.EOF {
-		return nil, p.errorf(tok, "unexpected %s", tok.Value)
+	p.forget(1)
+
+	els, err := parseSubStmt(p)
+	if err != nil {
+		return nil, err
 	}
 
+	return ast.NewIfStmt(cond, then, els), nil
+}
+
+// http://es5.github.io/#x12.6.2
+func parseWhileStmt(p *Parser) (ast.Node, error) {
 	p.forget(1)
 
-	return member, nil
-}
+	cond, err := parseCondition(p)
+	if err != nil {
+		return nil, err
+	}
 
-// state:
-// lookahead[0] = token.LParen
-func parseMemberFuncall(p *Parser, member *ast.MemberExpr) (ast.Node, error) {
-	p.forget(1) // drops (
-	args, err := parseFuncallArgs(p)
+	body, err := parseLoopBody(p)
 	if err != nil {
 		return nil, err
 	}
 
-	return ast.NewCallExpr(member, args), nil
+	return ast.NewWhileStmt(cond, body), nil
 }
 
-func parseFuncallArgs(p *Parser) ([]ast.Node, error) {
-	if len(p.lookahead) != 0 {
-		panic(fmt.Sprintf("parser: funcall args: unexpected non empty lookahead:%s", p.lookahead))
-	}
+// http://es5.github.io/#x12.6.1
+func parseDoWhileStmt(p *Parser) (ast.Node, error) {
+	p.forget(1)
 
-	nextToken := func() lexer.Tokval {
-		p.scry(1)
-		return p.lookahead[0]
+	body, err := parseLoopBody(p)
+	if err != nil {
+		return nil, err
 	}
 
-	var args []ast.Node
-
-	for {
-		tok := nextToken()
+	_, err = p.expect(token.While)
+	if err != nil {
+		return nil, err
+	}
 
-		if tok.Type == token.RParen {
-			p.forget(1)
-			break
-		}
-		// TODO: not handling errors like successive commas
-		if tok.Type == token.Comma {
-			p.forget(1)
-			continue
-		}
+	cond, err := parseCondition(p)
+	if err != nil {
+		return nil, err
+	}
 
-		parser, hasParser := literalParsers[tok.Type]
-		if hasParser {
-			parsed, err := parser(p)
-			if err != nil {
-				return nil, err
-			}
-			args = append(args, parsed)
-		} else {
-			return nil, p.errorf(tok, "parser: funcall args: unexpected token [%s]", tok.Value)
-		}
+	// WHY: a semicolon is always inserted after do-while
+	// if missing, even in the same line.
+	if p.peek().Type == token.SemiColon {
+		p.forget(1)
 	}
 
-	return args, nil
+	return ast.NewDoWhileStmt(body, cond), nil
 }
 
-// state:
-// lookahead[0] = token.Ident
-// lookahead[1] = token.LParen
-func parseCallExpr(p *Parser) (ast.Node, error) {
-	ident := p.lookahead[0]
-	p.forget(2) // drops <ident>(
-	args, err := parseFuncallArgs(p)
+// http://es5.github.io/#x12.6.3
+func parseForStmt(p *Parser) (ast.Node, error) {
+	p.forget(1)
+
+	_, err := p.expect(token.LParen)
 	if err != nil {
 		return nil, err
 	}
-	return ast.NewCallExpr(ast.NewIdent(ident.Value), args), nil
-}
 
-func parseFundecl(p *Parser) (ast.Node, error) {
-	p.forget(1)
-	tok := p.next()
-	if tok.Type != token.Ident {
-		return nil, p.errorf(tok, "parser: fundecl: Unexpected [%s]", tok.Value)
+	var init, cond, post ast.Node
+
+	p.noIn = true
+
+	switch p.peek().Type {
+	case token.SemiColon:
+	case token.Var:
+		p.forget(1)
+		init, err = parseVarDeclList(p)
+	default:
+		init, err = parseExpr(p)
 	}
 
-	ident := ast.NewIdent(tok.Value)
+	p.noIn = false
 
-	args, err := parseFunargs(p)
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := parseFunbody(p)
+	if tok := p.peek(); tok.Type == token.In {
+		return parseForInStmt(p, tok, init)
+	}
+
+	_, err = p.expect(token.SemiColon)
 	if err != nil {
 		return nil, err
 	}
 
-	return ast.NewFunDecl(ident, args, body), nil
-}
-
-func parseFunargs(p *Parser) ([]ast.Ident, error) {
-	tok := p.next()
-	if tok.Type != token.LParen {
-		return nil, p.errorf(tok, "parser: funargs: unexpected [%s]", tok.Value)
+	if p.peek().Type != token.SemiColon {
+		cond, err = parseExpr(p)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	var args []ast.Ident
-	tok = p.next()
-	if tok.Type == token.RParen {
-		return args, nil
+	_, err = p.expect(token.SemiColon)
+	if err != nil {
+		return nil, err
 	}
 
-	for tok.Type == token.Ident {
-		args = append(args, ast.NewIdent(tok.Value))
-		tok = p.next()
-		if tok.Type != token.Comma {
-			break
+	if p.peek().Type != token.RParen {
+		post, err = parseExpr(p)
+		if err != nil {
+			return nil, err
 		}
-		tok = p.next()
 	}
 
-	if tok.Type != token.RParen {
-		return nil, p.errorf(tok, "parser: funargs: unexpected [%s]", tok.Value)
+	_, err = p.expect(token.RParen)
+	if err != nil {
+		return nil, err
 	}
 
-	return args, nil
+	body, err := parseLoopBody(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.NewForStmt(init, cond, post, body), nil
 }
 
-func parseFunbody(p *Parser) (*ast.Program, error) {
-	tok := p.next()
-	if tok.Type != token.LBrace {
-		return nil, p.errorf(tok, "parser: funbody: unexpected [%s]", tok.Value)
+// parseForInStmt parses the rest of the for-in statement after
+// target, the token tok is the 'in' keyword.
+// http://es5.github.io/#x12.6.4
+func parseForInStmt(p *Parser, tok lexer.Tokval, target ast.Node) (ast.Node, error) {
+	switch target.Type() {
+	case ast.NodeVarDecls:
+		if len(target.(ast.VarDecls)) != 1 {
+			return nil, p.errorf(tok, "invalid left-hand side in for-in")
+		}
+	case ast.NodeIdent:
+		if err := p.checkStrictBinding(tok, target); err != nil {
+			return nil, err
+		}
+	case ast.NodeMemberExpr, ast.NodeCallExpr:
+	default:
+		return nil, p.errorf(tok, "invalid left-hand side in for-in")
+	}
+
+	p.forget(1)
+
+	obj, err := parseExpr(p)
+	if err != nil {
+		return nil, err
 	}
 
-	nbraces := p.openbraces
-	p.openbraces++
-	body, err := p.parse()
+	_, err = p.expect(token.RParen)
 	if err != nil {
 		return nil, err
 	}
 
-	if p.openbraces != nbraces {
-		return nil, p.errorf(tok, "parser: funbody: expected '}' but found EOF")
+	body, err := parseLoopBody(p)
+	if err != nil {
+		return nil, err
 	}
 
-	return body, nil
+	return ast.NewForInStmt(target, obj, body), nil
+}
+
+// parseWithStmt rejects the with statement in strict mode code.
+// TODO(i4k): implement the with statement of non-strict code.
+// http://es5.github.io/#x12.10
+func parseWithStmt(p *Parser) (ast.Node, error) {
+	tok := p.peek()
+	if p.strict {
+		return nil, p.errorf(tok, "Strict mode code may not include "+
+			"a with statement")
+	}
+
+	return nil, p.errorf(tok, "unexpected %s", tok.Value)
+}
+
+// http://es5.github.io/#x12.11
+func parseSwitchStmt(p *Parser) (ast.Node, error) {
+	p.forget(1)
+
+	discriminant, err := parseCondition(p)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.expect(token.LBrace)
+	if err != nil {
+		return nil, err
+	}
+
+	inswitch := p.inswitch
+	p.inswitch = true
+	defer func() { p.inswitch = inswitch }()
+
+	var (
+		cases      []ast.CaseClause
+		hasDefault bool
+	)
+
+	for {
+		tok := p.next()
+
+		var test ast.Node
+
+		switch tok.Type {
+		case token.RBrace:
+			return ast.NewSwitchStmt(discriminant, cases), nil
+		case token.Case:
+			test, err = parseExpr(p)
+			if err != nil {
+				return nil, err
+			}
+		case token.Default:
+			if hasDefault {
+				return nil, p.errorf(tok,
+					"more than one default clause in switch statement")
+			}
+
+			hasDefault = true
+		default:
+			return nil, p.errorf(tok, "unexpected %s", tok.Value)
+		}
+
+		_, err = p.expect(token.Colon)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := parseCaseBody(p)
+		if err != nil {
+			return nil, err
+		}
+
+		cases = append(cases, ast.NewCaseClause(test, body))
+	}
+}
+
+// parseCaseBody parses the statements of a case clause,
+// that ends at the next clause or at the end of the switch.
+func parseCaseBody(p *Parser) ([]ast.Node, error) {
+	var nodes []ast.Node
+
+	for {
+		tok := p.peek()
+
+		switch tok.Type {
+		case token.Case, token.Default, token.RBrace:
+			return nodes, nil
+		case token.EOF:
+			return nil, p.errorf(tok, "unexpected EOF")
+		}
+
+		node, err := p.parseStmt()
+		if err != nil {
+			return nil, err
+		}
+
+		if node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+}
+
+// http://es5.github.io/#x12.13
+func parseThrowStmt(p *Parser) (ast.Node, error) {
+	tok := p.next()
+
+	// no line terminator is allowed between throw and its expression
+	next := p.peek()
+	if next.Line > tok.Line {
+		return nil, p.errorf(next, "illegal newline after throw")
+	}
+
+	val, err := parseExpr(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.NewThrowStmt(val), p.endStmt()
+}
+
+// http://es5.github.io/#x12.14
+func parseTryStmt(p *Parser) (ast.Node, error) {
+	p.forget(1)
+
+	body, err := parseTryBlock(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		param          ast.Ident
+		catch, finally *ast.BlockStmt
+	)
+
+	if p.peek().Type == token.Catch {
+		p.forget(1)
+
+		_, err = p.expect(token.LParen)
+		if err != nil {
+			return nil, err
+		}
+
+		tok, err := p.expect(token.Ident)
+		if err != nil {
+			return nil, err
+		}
+
+		param = ast.NewIdent(tok.Value)
+		if err := p.checkStrictBinding(tok, param); err != nil {
+			return nil, err
+		}
+
+		_, err = p.expect(token.RParen)
+		if err != nil {
+			return nil, err
+		}
+
+		catch, err = parseTryBlock(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if tok := p.peek(); tok.Type == token.Finally {
+		p.forget(1)
+
+		finally, err = parseTryBlock(p)
+		if err != nil {
+			return nil, err
+		}
+	} else if catch == nil {
+		return nil, p.errorf(tok, "missing catch or finally after try")
+	}
+
+	return ast.NewTryStmt(body, param, catch, finally), nil
+}
+
+// parseTryBlock parses the blocks of the try statement,
+// they can't be replaced by other statements.
+func parseTryBlock(p *Parser) (*ast.BlockStmt, error) {
+	tok := p.peek()
+	if tok.Type != token.LBrace {
+		return nil, p.errorf(tok, "unexpected %s, expected %s",
+			tok.Value, token.LBrace)
+	}
+
+	block, err := parseBlockStmt(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return block.(*ast.BlockStmt), nil
+}
+
+// http://es5.github.io/#x12.8
+func parseBreakStmt(p *Parser) (ast.Node, error) {
+	tok := p.next()
+	label := parseStmtLabel(p, tok)
+
+	if label == nil {
+		if !p.inloop && !p.inswitch {
+			return nil, p.errorf(tok, "illegal break statement")
+		}
+	} else if _, ok := p.label(label); !ok {
+		return nil, p.errorf(tok, "undefined label '%s'", label)
+	}
+
+	return ast.NewBreakStmt(label), p.endStmt()
+}
+
+// http://es5.github.io/#x12.7
+func parseContinueStmt(p *Parser) (ast.Node, error) {
+	tok := p.next()
+	label := parseStmtLabel(p, tok)
+
+	if !p.inloop {
+		return nil, p.errorf(tok, "illegal continue statement")
+	}
+
+	if label != nil {
+		l, ok := p.label(label)
+		if !ok {
+			return nil, p.errorf(tok, "undefined label '%s'", label)
+		}
+
+		if !l.loop {
+			return nil, p.errorf(tok, "illegal continue statement: "+
+				"'%s' does not denote an iteration statement", label)
+		}
+	}
+
+	return ast.NewContinueStmt(label), p.endStmt()
+}
+
+// parseStmtLabel parses the optional label of the break and
+// continue statements, tok is the keyword. No line terminator
+// is allowed between the keyword and the label.
+func parseStmtLabel(p *Parser, tok lexer.Tokval) ast.Ident {
+	next := p.peek()
+	if next.Type != token.Ident || next.Line > tok.Line {
+		return nil
+	}
+
+	p.forget(1)
+	return ast.NewIdent(next.Value)
+}
+
+// atLabel tells if the next tokens are the label of a statement.
+func (p *Parser) atLabel() bool {
+	if p.peek().Type != token.Ident {
+		return false
+	}
+
+	if len(p.lookahead) < 2 {
+		p.scry(1)
+	}
+
+	return len(p.lookahead) > 1 && p.lookahead[1].Type == token.Colon
+}
+
+// label returns the enclosing label with the given name.
+func (p *Parser) label(name ast.Ident) (label, bool) {
+	for _, l := range p.labels {
+		if l.name == name.String() {
+			return l, true
+		}
+	}
+
+	return label{}, false
+}
+
+// parseLabeledStmt parses the labeled statement. Consecutive labels
+// are parsed together because all of them label an iteration
+// statement, eg.: a: b: while (x) continue a;
+// http://es5.github.io/#x12.12
+func parseLabeledStmt(p *Parser) (ast.Node, error) {
+	var names []ast.Ident
+
+	for p.atLabel() {
+		tok := p.next()
+		p.forget(1) // drops :
+
+		name := ast.NewIdent(tok.Value)
+		if _, ok := p.label(name); ok {
+			return nil, p.errorf(tok, "label '%s' has already been declared", name)
+		}
+
+		for _, other := range names {
+			if other.Equal(name) {
+				return nil, p.errorf(tok, "label '%s' has already been declared", name)
+			}
+		}
+
+		names = append(names, name)
+	}
+
+	var loop bool
+	switch p.peek().Type {
+	case token.While, token.Do, token.For:
+		loop = true
+	}
+
+	labels := p.labels
+	for _, name := range names {
+		p.labels = append(p.labels, label{name: name.String(), loop: loop})
+	}
+	defer func() { p.labels = labels }()
+
+	stmt, err := parseSubStmt(p)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(names) - 1; i >= 0; i-- {
+		stmt = ast.NewLabeledStmt(names[i], stmt)
+	}
+
+	return stmt, nil
+}
+
+// parseCondition parses the parenthesized expression
+// of if and while statements.
+func parseCondition(p *Parser) (ast.Node, error) {
+	_, err := p.expect(token.LParen)
+	if err != nil {
+		return nil, err
+	}
+
+	cond, err := parseExpr(p)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.expect(token.RParen)
+	return cond, err
+}
+
+// parseLoopBody parses the body of iteration statements,
+// where break and continue are allowed.
+func parseLoopBody(p *Parser) (ast.Node, error) {
+	inloop := p.inloop
+	p.inloop = true
+	defer func() { p.inloop = inloop }()
+
+	return parseSubStmt(p)
+}
+
+// parseSubStmt parses the statement that is part of other statements
+// (eg.: the body of if and loops). Empty statements are kept as empty
+// blocks.
+func parseSubStmt(p *Parser) (ast.Node, error) {
+	tok := p.peek()
+	if tok.Type == token.EOF || tok.Type == token.RBrace {
+		return nil, p.errorf(tok, "unexpected %s", tok.Value)
+	}
+
+	stmt, err := p.parseStmt()
+	if err != nil {
+		return nil, err
+	}
+
+	if stmt == nil {
+		return ast.NewBlockStmt(nil), nil
+	}
+
+	return stmt, nil
+}
+
+func parseExprStmt(p *Parser) (ast.Node, error) {
+	expr, err := parseExpr(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return expr, p.endStmt()
+}
+
+// parseExpr parses an expression, that is a comma
+// separated sequence of assignment expressions.
+// http://es5.github.io/#x11.14
+func parseExpr(p *Parser) (ast.Node, error) {
+	expr, err := parseAssignExpr(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().Type != token.Comma {
+		return expr, nil
+	}
+
+	exprs := []ast.Node{expr}
+	for p.peek().Type == token.Comma {
+		p.forget(1)
+
+		expr, err := parseAssignExpr(p)
+		if err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, expr)
+	}
+
+	return ast.NewSequenceExpr(exprs), nil
+}
+
+// parseAssignExpr parses assignments. Targets that can't be a
+// reference are rejected here, before any code runs.
+// http://es5.github.io/#x11.13
+func parseAssignExpr(p *Parser) (ast.Node, error) {
+	target, err := parseConditionalExpr(p)
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	if !token.IsAssignOperator(tok.Type) {
+		return target, nil
+	}
+
+	err = p.checkAssignTarget(tok, target, "Invalid left-hand side in assignment")
+	if err != nil {
+		return nil, err
+	}
+
+	p.forget(1)
+
+	// assignment is right associative: a = b = c is a = (b = c)
+	value, err := parseAssignExpr(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.NewAssignExpr(tok.Type, target, value), nil
+}
+
+// parseConditionalExpr parses the conditional operator, that is
+// right associative: a ? b : c ? d : e is a ? b : (c ? d : e)
+// http://es5.github.io/#x11.12
+func parseConditionalExpr(p *Parser) (ast.Node, error) {
+	cond, err := parseBinaryExpr(p, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().Type != token.Ternary {
+		return cond, nil
+	}
+
+	p.forget(1)
+
+	then, err := p.withIn(parseAssignExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.expect(token.Colon)
+	if err != nil {
+		return nil, err
+	}
+
+	els, err := parseAssignExpr(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.NewConditionalExpr(cond, then, els), nil
+}
+
+// parseBinaryExpr parses binary expressions using precedence climbing.
+// Only operators with precedence greater or equal to minprec are
+// handled, the others are left for the callers.
+func parseBinaryExpr(p *Parser, minprec int) (ast.Node, error) {
+	left, err := parseUnaryExpr(p)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		prec, ok := binaryPrecedence[tok.Type]
+		if !ok || prec < minprec || (tok.Type == token.In && p.noIn) {
+			return left, nil
+		}
+
+		p.forget(1)
+
+		// all binary operators are left associative
+		right, err := parseBinaryExpr(p, prec+1)
+		if err != nil {
+			return nil, err
+		}
+
+		left = ast.NewBinaryExpr(tok.Type, left, right)
+	}
+}
+
+// http://es5.github.io/#x11.4
+func parseUnaryExpr(p *Parser) (ast.Node, error) {
+	tok := p.peek()
+	if isUpdateOperator(tok.Type) {
+		p.forget(1)
+		operand, err := parseUnaryExpr(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return newUpdateExpr(p, tok, operand, true)
+	}
+
+	if !token.IsUnaryOperator(tok.Type) {
+		return parsePostfixExpr(p)
+	}
+
+	p.forget(1)
+	operand, err := parseUnaryExpr(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// http://es5.github.io/#x11.4.1
+	if p.strict && tok.Type == token.Delete && operand.Type() == ast.NodeIdent {
+		return nil, p.errorf(tok, "Delete of an unqualified identifier "+
+			"in strict mode.")
+	}
+
+	return ast.NewUnaryExpr(tok.Type, operand), nil
+}
+
+// parsePostfixExpr parses a left hand side expression followed
+// by an optional ++ or --. A line terminator before the operator
+// ends the expression, the operator then is the prefix of the
+// next statement.
+// http://es5.github.io/#x11.3
+func parsePostfixExpr(p *Parser) (ast.Node, error) {
+	expr, err := parseLeftHandSideExpr(p)
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	if !isUpdateOperator(tok.Type) || tok.Line > p.prev.Line {
+		return expr, nil
+	}
+
+	p.forget(1)
+	return newUpdateExpr(p, tok, expr, false)
+}
+
+// newUpdateExpr creates the ++ or -- expression, rejecting operands
+// that can't be a reference and, in strict mode code, eval and
+// arguments. Calls are reported at runtime as a ReferenceError.
+// http://es5.github.io/#x11.3.1
+// http://es5.github.io/#x11.4.4
+func newUpdateExpr(
+	p *Parser, tok lexer.Tokval, operand ast.Node, prefix bool,
+) (ast.Node, error) {
+	msg := "Invalid left-hand side expression in postfix operation"
+	if prefix {
+		msg = "Invalid left-hand side expression in prefix operation"
+	}
+
+	if err := p.checkAssignTarget(tok, operand, msg); err != nil {
+		return nil, err
+	}
+
+	return ast.NewUpdateExpr(tok.Type, operand, prefix), nil
+}
+
+// checkAssignTarget is the early error of assignments to left hand
+// side expressions that can't be a reference, only identifiers,
+// property accessors and calls can be. The call result is checked
+// at runtime.
+// http://es5.github.io/#x16
+func (p *Parser) checkAssignTarget(tok lexer.Tokval, target ast.Node, msg string) error {
+	switch target.Type() {
+	case ast.NodeIdent, ast.NodeMemberExpr, ast.NodeCallExpr:
+		return p.checkStrictBinding(tok, target)
+	}
+
+	return p.errorf(tok, "%s", msg)
+}
+
+// checkStrictBinding rejects eval and arguments as the name declared
+// or assigned by n in strict mode code.
+// http://es5.github.io/#x12.2.1
+// http://es5.github.io/#x11.13.1
+func (p *Parser) checkStrictBinding(tok lexer.Tokval, n ast.Node) error {
+	if p.strict && isEvalOrArguments(n) {
+		return p.errorf(tok, "Unexpected eval or arguments in strict mode")
+	}
+
+	return nil
+}
+
+func isUpdateOperator(t token.Type) bool {
+	return t == token.Inc || t == token.Dec
+}
+
+func isEvalOrArguments(n ast.Node) bool {
+	if n.Type() != ast.NodeIdent {
+		return false
+	}
+
+	name := n.(ast.Ident).String()
+	return name == "eval" || name == "arguments"
+}
+
+// parseLeftHandSideExpr parses a primary expression followed by
+// its property accesses and calls, or a new expression.
+// http://es5.github.io/#x11.2
+func parseLeftHandSideExpr(p *Parser) (ast.Node, error) {
+	if p.peek().Type == token.New {
+		return parseNewExpr(p)
+	}
+
+	expr, err := parsePrimaryExpr(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMemberOrCall(p, expr)
+}
+
+// http://es5.github.io/#x11.1
+func parsePrimaryExpr(p *Parser) (ast.Node, error) {
+	tok := p.peek()
+
+	if parser, ok := literalParsers[tok.Type]; ok {
+		return parser(p)
+	}
+
+	switch tok.Type {
+	case token.Ident:
+		p.forget(1)
+		return ast.NewIdent(tok.Value), nil
+	case token.This:
+		p.forget(1)
+		return ast.NewThis(), nil
+	case token.LParen:
+		return p.withIn(parseParenExpr)
+	case token.Function:
+		return p.withIn(parseFunExpr)
+	case token.LBrace:
+		return p.withIn(parseObjectLiteral)
+	case token.LBrack:
+		return p.withIn(parseArrayLiteral)
+	case token.Illegal:
+		return parseIllegal(p)
+	}
+
+	return nil, p.errorf(tok, "unexpected %s", tok.Value)
+}
+
+// withIn runs parse with the in operator allowed, the expressions
+// enclosed by delimiters are never ambiguous with for-in.
+func (p *Parser) withIn(parse parserfn) (ast.Node, error) {
+	saved := p.noIn
+	p.noIn = false
+
+	defer func() {
+		p.noIn = saved
+	}()
+
+	return parse(p)
+}
+
+// state:
+// lookahead[0] = token.LBrace
+// http://es5.github.io/#x11.1.5
+func parseObjectLiteral(p *Parser) (ast.Node, error) {
+	p.forget(1)
+
+	var (
+		props []ast.Property
+		defs  = map[string]*propDefs{}
+	)
+
+	for p.peek().Type != token.RBrace {
+		tok := p.peek()
+
+		prop, err := parseProperty(p)
+		if err != nil {
+			return nil, err
+		}
+
+		name := prop.Name.String()
+		if defs[name] == nil {
+			defs[name] = &propDefs{}
+		}
+
+		err = defs[name].add(p, tok, prop.Kind)
+		if err != nil {
+			return nil, err
+		}
+
+		props = append(props, prop)
+
+		// a trailing comma is allowed
+		if p.peek().Type == token.RBrace {
+			break
+		}
+
+		_, err = p.expect(token.Comma)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p.forget(1) // drops }
+
+	return ast.NewObjectLiteral(props), nil
+}
+
+// state:
+// lookahead[0] = token.LBrack
+// http://es5.github.io/#x11.1.4
+func parseArrayLiteral(p *Parser) (ast.Node, error) {
+	p.forget(1)
+
+	var elems []ast.Node
+
+	for p.peek().Type != token.RBrack {
+		// elision, the comma after an element is consumed
+		// below then every comma found here is a hole.
+		if p.peek().Type == token.Comma {
+			p.forget(1)
+			elems = append(elems, nil)
+			continue
+		}
+
+		elem, err := parseAssignExpr(p)
+		if err != nil {
+			return nil, err
+		}
+
+		elems = append(elems, elem)
+
+		// a trailing comma does not add an element
+		if p.peek().Type == token.RBrack {
+			break
+		}
+
+		_, err = p.expect(token.Comma)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p.forget(1) // drops ]
+
+	return ast.NewArrayLiteral(elems), nil
+}
+
+// propDefs keeps how a property was defined in an object
+// literal, required to validate duplicated names.
+type propDefs struct {
+	data, get, set bool
+}
+
+// add validates the definition of the property of kind
+// against the previous ones with the same name.
+// http://es5.github.io/#x11.1.5
+func (d *propDefs) add(p *Parser, tok lexer.Tokval, kind ast.PropertyKind) error {
+	switch kind {
+	case ast.PropInit:
+		if d.data && p.strict {
+			return p.errorf(tok, "duplicate data property in object literal "+
+				"not allowed in strict mode")
+		}
+
+		d.data = true
+		if d.get || d.set {
+			return p.errorf(tok, "object literal may not have data and "+
+				"accessor property with the same name")
+		}
+	case ast.PropGet, ast.PropSet:
+		if d.data {
+			return p.errorf(tok, "object literal may not have data and "+
+				"accessor property with the same name")
+		}
+
+		if (kind == ast.PropGet && d.get) || (kind == ast.PropSet && d.set) {
+			return p.errorf(tok, "object literal may not have multiple "+
+				"get/set accessors with the same name")
+		}
+
+		d.get = d.get || kind == ast.PropGet
+		d.set = d.set || kind == ast.PropSet
+	}
+
+	return nil
+}
+
+// parseProperty parses a property definition of an object literal.
+func parseProperty(p *Parser) (ast.Property, error) {
+	tok := p.peek()
+
+	accessor := tok.Type == token.Ident &&
+		(tok.Value.String() == "get" || tok.Value.String() == "set")
+
+	name, err := parsePropertyName(p)
+	if err != nil {
+		return ast.Property{}, err
+	}
+
+	if accessor && p.peek().Type != token.Colon {
+		return parseAccessor(p, tok)
+	}
+
+	_, err = p.expect(token.Colon)
+	if err != nil {
+		return ast.Property{}, err
+	}
+
+	value, err := parseAssignExpr(p)
+	if err != nil {
+		return ast.Property{}, err
+	}
+
+	return ast.NewProperty(ast.PropInit, name, value), nil
+}
+
+// parseAccessor parses the getter or setter definition,
+// kind is the get or set token already consumed.
+func parseAccessor(p *Parser, kind lexer.Tokval) (ast.Property, error) {
+	name, err := parsePropertyName(p)
+	if err != nil {
+		return ast.Property{}, err
+	}
+
+	args, err := parseFunargs(p)
+	if err != nil {
+		return ast.Property{}, err
+	}
+
+	propkind := ast.PropGet
+	if kind.Value.String() == "set" {
+		propkind = ast.PropSet
+		if len(args) != 1 {
+			return ast.Property{}, p.errorf(kind,
+				"setter must have exactly one formal parameter")
+		}
+	} else if len(args) != 0 {
+		return ast.Property{}, p.errorf(kind,
+			"getter must not have any formal parameters")
+	}
+
+	body, err := parseFunbody(p)
+	if err != nil {
+		return ast.Property{}, err
+	}
+
+	return ast.NewProperty(propkind, name, ast.NewFunExpr(nil, args, body)), nil
+}
+
+// parsePropertyName parses the name of a property, that can be an
+// identifier (including reserved words), a string or a number.
+// Numbers are converted to their string representation.
+func parsePropertyName(p *Parser) (utf16.Str, error) {
+	tok := p.peek()
+
+	switch {
+	case isIdentifierName(tok.Type), tok.Type == token.String:
+		p.forget(1)
+		return tok.Value, nil
+	case tok.Type == token.Decimal, tok.Type == token.Hexadecimal:
+		num, err := literalParsers[tok.Type](p)
+		if err != nil {
+			return nil, err
+		}
+
+		val := num.(ast.Number).Value()
+		return utf16.Str(types.Number(val).ToString()), nil
+	}
+
+	return nil, p.errorf(tok, "unexpected %s", tok.Value)
+}
+
+// isIdentifierName tells if tokens of type t are identifier names,
+// ie. identifiers or reserved words, allowed as property names.
+// http://es5.github.io/#x7.6
+func isIdentifierName(t token.Type) bool {
+	return t == token.Ident || t == token.Bool || t == token.Null ||
+		t == token.Undefined || token.IsKeyword(t)
+}
+
+// state:
+// lookahead[0] = token.LParen
+func parseParenExpr(p *Parser) (ast.Node, error) {
+	p.forget(1)
+
+	expr, err := parseExpr(p)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.expect(token.RParen)
+	return expr, err
+}
+
+// state:
+// lookahead[0] = token.Dot
+func parseMemberExpr(p *Parser, object ast.Node) (ast.Node, error) {
+	p.forget(1)
+
+	tok := p.next()
+	if !isIdentifierName(tok.Type) {
+		return nil, p.errorf(tok, "unexpected %s", tok.Value)
+	}
+
+	return ast.NewMemberExpr(object, ast.NewIdent(tok.Value)), nil
+}
+
+// state:
+// lookahead[0] = token.LBrack
+func parseComputedMemberExpr(p *Parser, object ast.Node) (ast.Node, error) {
+	p.forget(1)
+
+	property, err := p.withIn(parseExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.expect(token.RBrack)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.NewComputedMemberExpr(object, property), nil
+}
+
+// parseMemberOrCall parses the chain of property accesses and
+// calls applied to expr, if any. Eg.: a.b[c](d).e
+// http://es5.github.io/#x11.2
+func parseMemberOrCall(p *Parser, expr ast.Node) (ast.Node, error) {
+	for {
+		var err error
+
+		expr, err = parseMembers(p, expr)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek().Type != token.LParen {
+			return expr, nil
+		}
+
+		expr, err = parseCallExpr(p, expr)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseMembers parses the property accesses applied to expr.
+func parseMembers(p *Parser, expr ast.Node) (ast.Node, error) {
+	for {
+		var err error
+
+		switch p.peek().Type {
+		case token.Dot:
+			expr, err = parseMemberExpr(p, expr)
+		case token.LBrack:
+			expr, err = parseComputedMemberExpr(p, expr)
+		default:
+			return expr, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// state:
+// lookahead[0] = token.New
+// http://es5.github.io/#x11.2.2
+func parseNewExpr(p *Parser) (ast.Node, error) {
+	newexpr, err := parseNewCallee(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseMemberOrCall(p, newexpr)
+}
+
+// parseNewCallee parses the constructor of a new expression and
+// its arguments, if any. The calls in the constructor expression
+// are not allowed because the first arguments found belong to the
+// new expression, eg.: new a.b(c) is the same as: new (a.b)(c).
+func parseNewCallee(p *Parser) (ast.Node, error) {
+	p.forget(1) // drops new
+
+	var (
+		callee ast.Node
+		err    error
+	)
+
+	if p.peek().Type == token.New {
+		callee, err = parseNewCallee(p)
+	} else {
+		callee, err = parsePrimaryExpr(p)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	callee, err = parseMembers(p, callee)
+	if err != nil {
+		return nil, err
+	}
+
+	var args []ast.Node
+	if p.peek().Type == token.LParen {
+		p.forget(1)
+		args, err = parseFuncallArgs(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ast.NewNewExpr(callee, args), nil
+}
+
+// state:
+// lookahead[0] = token.LParen
+func parseCallExpr(p *Parser, callee ast.Node) (ast.Node, error) {
+	p.forget(1) // drops (
+	args, err := parseFuncallArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.NewCallExpr(callee, args), nil
+}
+
+func parseFuncallArgs(p *Parser) ([]ast.Node, error) {
+	var args []ast.Node
+
+	saved := p.noIn
+	p.noIn = false
+
+	defer func() {
+		p.noIn = saved
+	}()
+
+	if p.peek().Type == token.RParen {
+		p.forget(1)
+		return args, nil
+	}
+
+	for {
+		arg, err := parseAssignExpr(p)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, arg)
+
+		tok := p.next()
+		if tok.Type == token.RParen {
+			return args, nil
+		}
+
+		if tok.Type != token.Comma {
+			return nil, p.errorf(tok, "funcall args: unexpected token [%s]", tok.Value)
+		}
+	}
+}
+
+func parseFundecl(p *Parser) (ast.Node, error) {
+	p.forget(1)
+	tok := p.next()
+	if tok.Type != token.Ident {
+		return nil, p.errorf(tok, "parser: fundecl: Unexpected [%s]", tok.Value)
+	}
+
+	ident := ast.NewIdent(tok.Value)
+
+	args, err := parseFunargs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := parseFunbody(p)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkStrictFunction(p, tok, ident, args, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.NewFunDecl(ident, args, body), nil
+}
+
+// state:
+// lookahead[0] = token.Function
+// http://es5.github.io/#x13
+func parseFunExpr(p *Parser) (ast.Node, error) {
+	p.forget(1)
+
+	var name ast.Ident
+	tok := p.peek()
+	if tok.Type == token.Ident {
+		p.forget(1)
+		name = ast.NewIdent(tok.Value)
+	}
+
+	args, err := parseFunargs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := parseFunbody(p)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkStrictFunction(p, tok, name, args, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.NewFunExpr(name, args, body), nil
+}
+
+// checkStrictFunction validates the name and parameters of a function
+// with a strict mode body. It's done after the body is parsed because
+// the function could be made strict by its own directive prologue.
+// http://es5.github.io/#x13.1
+func checkStrictFunction(
+	p *Parser, tok lexer.Tokval, name ast.Ident, args []ast.Ident, body *ast.Program,
+) error {
+	if !body.Strict {
+		return nil
+	}
+
+	if isEvalOrArguments(name) {
+		return p.errorf(tok, "Unexpected eval or arguments in strict mode")
+	}
+
+	seen := map[string]bool{}
+	for _, arg := range args {
+		if isEvalOrArguments(arg) {
+			return p.errorf(tok, "Unexpected eval or arguments in strict mode")
+		}
+
+		if seen[arg.String()] {
+			return p.errorf(tok, "Duplicate parameter name not allowed "+
+				"in this context")
+		}
+
+		seen[arg.String()] = true
+	}
+
+	return nil
+}
+
+func parseFunargs(p *Parser) ([]ast.Ident, error) {
+	tok := p.next()
+	if tok.Type != token.LParen {
+		return nil, p.errorf(tok, "parser: funargs: unexpected [%s]", tok.Value)
+	}
+
+	var args []ast.Ident
+	tok = p.next()
+	if tok.Type == token.RParen {
+		return args, nil
+	}
+
+	for tok.Type == token.Ident {
+		args = append(args, ast.NewIdent(tok.Value))
+		tok = p.next()
+		if tok.Type != token.Comma {
+			break
+		}
+		tok = p.next()
+	}
+
+	if tok.Type != token.RParen {
+		return nil, p.errorf(tok, "parser: funargs: unexpected [%s]", tok.Value)
+	}
+
+	return args, nil
+}
+
+func parseFunbody(p *Parser) (*ast.Program, error) {
+	tok := p.next()
+	if tok.Type != token.LBrace {
+		return nil, p.errorf(tok, "parser: funbody: unexpected [%s]", tok.Value)
+	}
+
+	// functions nested in strict mode code are strict too, then
+	// only the directives of the body can change strict.
+	infunc, inloop, inswitch, labels := p.infunc, p.inloop, p.inswitch, p.labels
+	strict := p.strict
+	p.infunc, p.inloop, p.inswitch, p.labels = true, false, false, nil
+	defer func() {
+		p.infunc, p.inloop, p.inswitch, p.labels = infunc, inloop, inswitch, labels
+		p.strict = strict
+	}()
+
+	directives, err := p.parseDirectives()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := p.parseStmts(token.RBrace)
+	if err != nil {
+		return nil, err
+	}
+
+	p.forget(1) // drops }
+
+	return &ast.Program{
+		Nodes:  append(directives, nodes...),
+		Strict: p.strict,
+	}, nil
 }
 
 // TODO(i4k): implement line and column of error
@@ -266,6 +266,29 @@ func TestKeywords(t *testing.T) {
 			code: "true",
 			want: boolean(true),
 		},
+		{
+			name: "This",
+			code: "this",
+			want: ast.NewThis(),
+		},
+		{
+			name: "ThisMember",
+			code: "this.a = this[b]",
+			want: assignExpr(token.Assign,
+				memberExpr(ast.NewThis(), "a"),
+				computedMemberExpr(ast.NewThis(), identifier("b")),
+			),
+		},
+		{
+			name: "ThisCall",
+			code: "this.a()",
+			want: callExpr(memberExpr(ast.NewThis(), "a"), nil),
+		},
+		{
+			name: "ThisIsNotAssignableName",
+			code: "var this = 1;",
+			fail: true,
+		},
 	})
 }
 
@@ -311,6 +334,176 @@ func TestMemberExpr(t *testing.T) {
 				"f",
 			),
 		},
+		{
+			name: "Computed",
+			code: `a[b]`,
+			want: computedMemberExpr(identifier("a"), identifier("b")),
+		},
+		{
+			name: "ComputedExpressions",
+			code: `a["a-b"][0][b + 1][b, c]`,
+			want: computedMemberExpr(
+				computedMemberExpr(
+					computedMemberExpr(
+						computedMemberExpr(identifier("a"), str("a-b")),
+						intNumber(0),
+					),
+					binaryExpr(token.Plus, identifier("b"), intNumber(1)),
+				),
+				ast.NewSequenceExpr([]ast.Node{identifier("b"), identifier("c")}),
+			),
+		},
+		{
+			name: "DotAndComputed",
+			code: `a.b[c].d`,
+			want: memberExpr(
+				computedMemberExpr(memberExpr(identifier("a"), "b"), identifier("c")),
+				"d",
+			),
+		},
+		{
+			name: "ComputedIsNotDot",
+			code: `a["b"]`,
+			want: computedMemberExpr(identifier("a"), str("b")),
+		},
+		{
+			name: "Literals",
+			code: `null[0]`,
+			want: computedMemberExpr(null(), intNumber(0)),
+		},
+		{
+			name:    "ErrorEmptyComputed",
+			code:    "a[]",
+			wantErr: E("tests.js:1:0: unexpected ]"),
+		},
+		{
+			name: "ErrorUnclosedComputed",
+			code: "a[b",
+			fail: true,
+		},
+	})
+}
+
+func TestMemberCallChains(t *testing.T) {
+	a, b, c, d := identifier("a"), identifier("b"), identifier("c"),
+		identifier("d")
+
+	runTests(t, []TestCase{
+		{
+			name: "MemberOfCall",
+			code: "a.b().c",
+			want: memberExpr(callExpr(memberExpr(a, "b"), nil), "c"),
+		},
+		{
+			name: "CallOfCall",
+			code: "a()()",
+			want: callExpr(callExpr(a, nil), nil),
+		},
+		{
+			name: "Mixed",
+			code: "a.b[c](d).e",
+			want: memberExpr(
+				callExpr(computedMemberExpr(memberExpr(a, "b"), c), []ast.Node{d}),
+				"e",
+			),
+		},
+		{
+			name: "ComputedOfCall",
+			code: "a(b)[c](d)",
+			want: callExpr(
+				computedMemberExpr(callExpr(a, []ast.Node{b}), c),
+				[]ast.Node{d},
+			),
+		},
+		{
+			name: "ArrayLiteral",
+			code: "[a][0]",
+			want: computedMemberExpr(ast.NewArrayLiteral([]ast.Node{a}), intNumber(0)),
+		},
+		{
+			name: "Assignment",
+			code: "a()[b].c = d",
+			want: assignExpr(token.Assign,
+				memberExpr(computedMemberExpr(callExpr(a, nil), b), "c"),
+				d,
+			),
+		},
+	})
+}
+
+func TestNewExpr(t *testing.T) {
+	a, b, c := identifier("a"), identifier("b"), identifier("c")
+
+	newExpr := func(callee ast.Node, args ...ast.Node) *ast.NewExpr {
+		return ast.NewNewExpr(callee, args)
+	}
+
+	runTests(t, []TestCase{
+		{
+			name: "WithoutArgs",
+			code: "new a",
+			want: newExpr(a),
+		},
+		{
+			name: "EmptyArgs",
+			code: "new a()",
+			want: newExpr(a),
+		},
+		{
+			name: "Args",
+			code: "new a(b, c + 1)",
+			want: newExpr(a, b, binaryExpr(token.Plus, c, intNumber(1))),
+		},
+		{
+			name: "MemberCallee",
+			code: "new a.b[c](1)",
+			want: newExpr(computedMemberExpr(memberExpr(a, "b"), c), intNumber(1)),
+		},
+		{
+			name: "MemberOfNew",
+			code: "new a().b",
+			want: memberExpr(newExpr(a), "b"),
+		},
+		{
+			name: "CallOfNew",
+			code: "new a(b)(c)",
+			want: callExpr(newExpr(a, b), []ast.Node{c}),
+		},
+		{
+			name: "NewOfNew",
+			code: "new new a(b)(c)",
+			want: newExpr(newExpr(a, b), c),
+		},
+		{
+			name: "NewOfNewWithoutArgs",
+			code: "new new a",
+			want: newExpr(newExpr(a)),
+		},
+		{
+			name: "CallIsNotCallee",
+			code: "new (a())",
+			want: newExpr(callExpr(a, nil)),
+		},
+		{
+			name: "FunExpr",
+			code: "new function () {}",
+			want: newExpr(funExpr(identifier(""), []ast.Ident{}, program())),
+		},
+		{
+			name: "Operand",
+			code: "new a + 1",
+			want: binaryExpr(token.Plus, newExpr(a), intNumber(1)),
+		},
+		{
+			name: "MissingCallee",
+			code: "new",
+			fail: true,
+		},
+		{
+			name: "UnclosedArgs",
+			code: "new a(b",
+			fail: true,
+		},
 	})
 }
 
@@ -393,7 +586,7 @@ func TestVarStatement(t *testing.T) {
 		{
 			name: "NoInitializer",
 			code: "var x;",
-			want: vars(identifier("x"), undefined()),
+			want: vars(identifier("x"), nil),
 		},
 		{
 			name: "Decimal",
@@ -743,111 +936,1703 @@ func TestFunDecl(t *testing.T) {
 	})
 }
 
-// TestCase is the description of an parser related test.
-// The fields want and wants are mutually exclusive, you should
-// never provide both. If "wants" is provided the "want" field will be ignored.
-//
-// This is supposed to make it easier to test single nodes and multiple nodes.
-type TestCase struct {
-	name    string
-	code    string
-	want    ast.Node
-	wants   []ast.Node
-	fail    bool
-	wantErr error
-}
-
-func (tc *TestCase) run(t *testing.T) {
-	t.Run(tc.name, func(t *testing.T) {
-		tree, err := parser.Parse("tests.js", tc.code)
-
-		if tc.fail && tc.wantErr == nil {
-			if err == nil {
-				t.Fatalf("expected an error got succeess:\n%s\n", tree)
-			}
-		} else {
-			assert.EqualErrs(t, tc.wantErr, err, "parser err")
-		}
-
-		if err != nil {
-			return
-		}
-
-		if tc.wants == nil {
-			assertEqualNodes(t, []ast.Node{tc.want}, tree.Nodes)
-			return
-		}
+func TestFunExpr(t *testing.T) {
+	noargs := []ast.Ident{}
 
-		assertEqualNodes(t, tc.wants, tree.Nodes)
+	runTests(t, []TestCase{
+		{
+			name: "Anonymous",
+			code: "var f = function() {}",
+			want: varDecls(varDecl(identifier("f"),
+				funExpr(identifier(""), noargs, program()))),
+		},
+		{
+			name: "Named",
+			code: "var f = function g(a, b) { return a }",
+			want: varDecls(varDecl(identifier("f"),
+				funExpr(identifier("g"),
+					[]ast.Ident{identifier("a"), identifier("b")},
+					program(ast.NewReturnStmt(identifier("a")))))),
+		},
+		{
+			name: "Callback",
+			code: "a(function(x) {}, 1)",
+			want: callExpr(identifier("a"), []ast.Node{
+				funExpr(identifier(""), []ast.Ident{identifier("x")}, program()),
+				intNumber(1),
+			}),
+		},
+		{
+			name: "IIFE",
+			code: "(function() {})()",
+			want: callExpr(funExpr(identifier(""), noargs, program()), nil),
+		},
+		{
+			name: "IIFEInsideParens",
+			code: "(function() {}())",
+			want: callExpr(funExpr(identifier(""), noargs, program()), nil),
+		},
+		{
+			name: "MemberOfFunExpr",
+			code: "(function() {}).length",
+			want: memberExpr(funExpr(identifier(""), noargs, program()), "length"),
+		},
+		{
+			name: "Operand",
+			code: "1 + function() {}",
+			want: binaryExpr(token.Plus,
+				intNumber(1),
+				funExpr(identifier(""), noargs, program())),
+		},
+		{
+			name: "MissingBody",
+			code: "var f = function()",
+			fail: true,
+		},
 	})
 }
 
-func runTests(t *testing.T, tcases []TestCase) {
-	for _, tcase := range tcases {
-		tcase.run(t)
-	}
-}
-
-func assertEqualNodes(t *testing.T, want []ast.Node, got []ast.Node) {
-	if len(want) != len(got) {
-		t.Errorf("want[%d] nodes but got[%d] nodes", len(want), len(got))
-		t.Fatalf("want:\n%v\n\ngot:\n%v\n", want, got)
-	}
-
-	for i, w := range want {
-		g := got[i]
-		if !w.Equal(g) {
-			t.Errorf("wanted node[%d][%v] != got node[%d][%v]", i, w, i, g)
-		}
+func TestReturnStmt(t *testing.T) {
+	fn := func(body ...ast.Node) ast.Node {
+		return fundecl(identifier("f"), []ast.Ident{}, program(body...))
 	}
-}
-
-func number(n float64) ast.Number {
-	return ast.NewNumber(n)
-}
-
-func intNumber(n int64) ast.Number {
-	return ast.NewIntNumber(n)
-}
-
-func identifier(val string) ast.Ident {
-	return ast.NewIdent(utf16.S(val))
-}
-
-func str(val string) ast.String {
-	return ast.NewString(utf16.S(val))
-}
 
-func null() ast.Null {
-	return ast.NewNull()
+	runTests(t, []TestCase{
+		{
+			name: "ReturnValue",
+			code: "function f() { return 1 }",
+			want: fn(ast.NewReturnStmt(intNumber(1))),
+		},
+		{
+			name: "ReturnExpr",
+			code: "function f() { return a + b; }",
+			want: fn(ast.NewReturnStmt(
+				binaryExpr(token.Plus, identifier("a"), identifier("b")))),
+		},
+		{
+			name: "ReturnNothing",
+			code: "function f() { return }",
+			want: fn(ast.NewReturnStmt(nil)),
+		},
+		{
+			name: "ReturnSemicolon",
+			code: "function f() { return; }",
+			want: fn(ast.NewReturnStmt(nil)),
+		},
+		{
+			name: "NoLineTerminatorAfterReturn",
+			code: `function f() {
+				return
+				1
+			}`,
+			want: fn(ast.NewReturnStmt(nil), intNumber(1)),
+		},
+		{
+			name: "ReturnAfterStmts",
+			code: "function f() { var a = 1; return a }",
+			want: fn(
+				varDecls(varDecl(identifier("a"), intNumber(1))),
+				ast.NewReturnStmt(identifier("a")),
+			),
+		},
+		{
+			name:    "ReturnOutsideFunction",
+			code:    "return 1",
+			wantErr: E("tests.js:1:0: illegal return statement"),
+		},
+		{
+			name:    "ReturnAfterFunction",
+			code:    "function f() {} return",
+			wantErr: E("tests.js:1:0: illegal return statement"),
+		},
+	})
 }
 
-func undefined() ast.Undefined {
-	return ast.NewUndefined()
+func TestBlockStmt(t *testing.T) {
+	runTests(t, []TestCase{
+		{
+			name: "Empty",
+			code: "{}",
+			want: blockStmt(),
+		},
+		{
+			name: "Stmts",
+			code: "{ a; var b = 1 }",
+			want: blockStmt(
+				identifier("a"),
+				varDecls(varDecl(identifier("b"), intNumber(1))),
+			),
+		},
+		{
+			name: "Nested",
+			code: "{ { a } b }",
+			want: blockStmt(blockStmt(identifier("a")), identifier("b")),
+		},
+		{
+			name: "InsideFunction",
+			code: "function f() { { return } }",
+			want: fundecl(identifier("f"), []ast.Ident{},
+				program(blockStmt(ast.NewReturnStmt(nil)))),
+		},
+		{
+			name: "EmptyStatements",
+			code: "{ ; ; }",
+			want: blockStmt(),
+		},
+		{
+			name:    "Unclosed",
+			code:    "{ a",
+			wantErr: E("tests.js:1:0: unexpected EOF"),
+		},
+		{
+			name:    "UnexpectedClose",
+			code:    "a }",
+			wantErr: E("tests.js:1:0: unexpected '}'"),
+		},
+	})
 }
 
-func boolean(b bool) ast.Bool {
-	return ast.NewBool(b)
-}
+func TestIfStmt(t *testing.T) {
+	a, b, c := identifier("a"), identifier("b"), identifier("c")
 
-func memberExpr(obj ast.Node, memberName string) *ast.MemberExpr {
-	return ast.NewMemberExpr(obj, identifier(memberName))
+	runTests(t, []TestCase{
+		{
+			name: "If",
+			code: "if (a) b",
+			want: ifStmt(a, b, nil),
+		},
+		{
+			name: "IfBlock",
+			code: "if (a) { b }",
+			want: ifStmt(a, blockStmt(b), nil),
+		},
+		{
+			name: "IfElse",
+			code: "if (a) { b } else { c }",
+			want: ifStmt(a, blockStmt(b), blockStmt(c)),
+		},
+		{
+			name: "IfElseNoBraces",
+			code: "if (a) b; else c",
+			want: ifStmt(a, b, c),
+		},
+		{
+			name: "IfElseNewLine",
+			code: `if (a) b
+			       else c`,
+			want: ifStmt(a, b, c),
+		},
+		{
+			name: "ElseIf",
+			code: "if (a) b; else if (c) a; else b",
+			want: ifStmt(a, b, ifStmt(c, a, b)),
+		},
+		{
+			name: "DanglingElse",
+			code: "if (a) if (b) c; else a",
+			want: ifStmt(a, ifStmt(b, c, a), nil),
+		},
+		{
+			name: "EmptyThen",
+			code: "if (a);",
+			want: ifStmt(a, blockStmt(), nil),
+		},
+		{
+			name: "CondExpr",
+			code: "if (a == 1 && b) c",
+			want: ifStmt(
+				binaryExpr(token.LAnd,
+					binaryExpr(token.Equal, a, intNumber(1)), b),
+				c, nil),
+		},
+		{
+			name: "ElseSameLine",
+			code: "if (a) b else c",
+			fail: true,
+		},
+		{
+			name: "NoParens",
+			code: "if a b",
+			fail: true,
+		},
+		{
+			name: "NoStmt",
+			code: "if (a)",
+			fail: true,
+		},
+	})
 }
 
-func callExpr(callee ast.Node, args []ast.Node) *ast.CallExpr {
-	return ast.NewCallExpr(callee, args)
-}
+func TestLoopStmts(t *testing.T) {
+	a, b, i := identifier("a"), identifier("b"), identifier("i")
 
-func fundecl(name ast.Ident, args []ast.Ident, body *ast.Program) *ast.FunDecl {
+	runTests(t, []TestCase{
+		{
+			name: "While",
+			code: "while (a) b",
+			want: ast.NewWhileStmt(a, b),
+		},
+		{
+			name: "WhileBlock",
+			code: "while (a) { b; break }",
+			want: ast.NewWhileStmt(a, blockStmt(b, ast.NewBreakStmt(nil))),
+		},
+		{
+			name: "DoWhile",
+			code: "do { a } while (b)",
+			want: ast.NewDoWhileStmt(blockStmt(a), b),
+		},
+		{
+			name: "DoWhileSemicolonInsertion",
+			code: "do a; while (b) a",
+			wants: []ast.Node{
+				ast.NewDoWhileStmt(a, b),
+				a,
+			},
+		},
+		{
+			name: "For",
+			code: "for (i = 0; i < 10; i += 1) a",
+			want: ast.NewForStmt(
+				assignExpr(token.Assign, i, intNumber(0)),
+				binaryExpr(token.Less, i, intNumber(10)),
+				assignExpr(token.AddAssign, i, intNumber(1)),
+				a,
+			),
+		},
+		{
+			name: "ForVar",
+			code: "for (var i = 0, a; i < 10;) { continue }",
+			want: ast.NewForStmt(
+				varDecls(varDecl(i, intNumber(0)), varDecl(a, nil)),
+				binaryExpr(token.Less, i, intNumber(10)),
+				nil,
+				blockStmt(ast.NewContinueStmt(nil)),
+			),
+		},
+		{
+			name: "ForEver",
+			code: "for (;;) {}",
+			want: ast.NewForStmt(nil, nil, nil, blockStmt()),
+		},
+		{
+			name: "BreakInsideIf",
+			code: "while (a) if (b) break",
+			want: ast.NewWhileStmt(a, ifStmt(b, ast.NewBreakStmt(nil), nil)),
+		},
+		{
+			name: "BreakNewLine",
+			code: `while (a) {
+				break
+				a
+			}`,
+			want: ast.NewWhileStmt(a, blockStmt(ast.NewBreakStmt(nil), a)),
+		},
+		{
+			name:    "BreakOutsideLoop",
+			code:    "break",
+			wantErr: E("tests.js:1:0: illegal break statement"),
+		},
+		{
+			name:    "ContinueOutsideLoop",
+			code:    "if (a) continue",
+			wantErr: E("tests.js:1:0: illegal continue statement"),
+		},
+		{
+			name:    "BreakInsideFunctionInsideLoop",
+			code:    "while (a) { function f() { break } }",
+			wantErr: E("tests.js:1:0: illegal break statement"),
+		},
+		{
+			name: "ForMissingSemicolon",
+			code: "for (i = 0) a",
+			fail: true,
+		},
+		{
+			name: "DoWithoutWhile",
+			code: "do a",
+			fail: true,
+		},
+	})
+}
+
+func TestForInStmt(t *testing.T) {
+	a, b, k := identifier("a"), identifier("b"), identifier("k")
+
+	runTests(t, []TestCase{
+		{
+			name: "ForIn",
+			code: "for (k in a) b",
+			want: ast.NewForInStmt(k, a, b),
+		},
+		{
+			name: "ForInVar",
+			code: "for (var k in a) { continue }",
+			want: ast.NewForInStmt(
+				varDecls(varDecl(k, nil)),
+				a,
+				blockStmt(ast.NewContinueStmt(nil)),
+			),
+		},
+		{
+			name: "ForInVarInitializer",
+			code: "for (var k = 1 in a) break",
+			want: ast.NewForInStmt(
+				varDecls(varDecl(k, intNumber(1))),
+				a,
+				ast.NewBreakStmt(nil),
+			),
+		},
+		{
+			name: "ForInMember",
+			code: "for (a.b in a) {}",
+			want: ast.NewForInStmt(memberExpr(a, "b"), a, blockStmt()),
+		},
+		{
+			name:    "ForInManyVars",
+			code:    "for (var k, b in a) {}",
+			wantErr: E("tests.js:1:0: invalid left-hand side in for-in"),
+		},
+		{
+			name:    "ForInLiteral",
+			code:    "for (1 in a) {}",
+			wantErr: E("tests.js:1:0: invalid left-hand side in for-in"),
+		},
+		{
+			name: "ForInMissingParen",
+			code: "for (k in a b",
+			fail: true,
+		},
+	})
+}
+
+func TestSwitchStmt(t *testing.T) {
+	a, b, c := identifier("a"), identifier("b"), identifier("c")
+	brk := ast.NewBreakStmt(nil)
+
+	runTests(t, []TestCase{
+		{
+			name: "EmptySwitch",
+			code: "switch (a) {}",
+			want: ast.NewSwitchStmt(a, nil),
+		},
+		{
+			name: "Cases",
+			code: "switch (a) { case 1: b; break; case 2: case 3: c }",
+			want: ast.NewSwitchStmt(a, []ast.CaseClause{
+				ast.NewCaseClause(intNumber(1), []ast.Node{b, brk}),
+				ast.NewCaseClause(intNumber(2), nil),
+				ast.NewCaseClause(intNumber(3), []ast.Node{c}),
+			}),
+		},
+		{
+			name: "DefaultInTheMiddle",
+			code: "switch (a) { case b: break; default: c; case 1: }",
+			want: ast.NewSwitchStmt(a, []ast.CaseClause{
+				ast.NewCaseClause(b, []ast.Node{brk}),
+				ast.NewCaseClause(nil, []ast.Node{c}),
+				ast.NewCaseClause(intNumber(1), nil),
+			}),
+		},
+		{
+			name: "ContinueInsideLoop",
+			code: "while (a) switch (b) { default: continue }",
+			want: ast.NewWhileStmt(a, ast.NewSwitchStmt(b, []ast.CaseClause{
+				ast.NewCaseClause(nil, []ast.Node{ast.NewContinueStmt(nil)}),
+			})),
+		},
+		{
+			name:    "ManyDefaults",
+			code:    "switch (a) { default: b; default: c }",
+			wantErr: E("tests.js:1:0: more than one default clause in switch statement"),
+		},
+		{
+			name:    "ContinueOutsideLoop",
+			code:    "switch (a) { case 1: continue }",
+			wantErr: E("tests.js:1:0: illegal continue statement"),
+		},
+		{
+			name:    "BreakInsideFunction",
+			code:    "switch (a) { case 1: function f() { break } }",
+			wantErr: E("tests.js:1:0: illegal break statement"),
+		},
+		{
+			name: "StmtBeforeCase",
+			code: "switch (a) { b; case 1: }",
+			fail: true,
+		},
+		{
+			name: "MissingColon",
+			code: "switch (a) { case 1 b }",
+			fail: true,
+		},
+	})
+}
+
+func TestTryStmt(t *testing.T) {
+	a, b, e := identifier("a"), identifier("b"), identifier("e")
+
+	runTests(t, []TestCase{
+		{
+			name: "Throw",
+			code: "throw a",
+			want: ast.NewThrowStmt(a),
+		},
+		{
+			name: "ThrowExpr",
+			code: "throw a + 1; b",
+			wants: []ast.Node{
+				ast.NewThrowStmt(binaryExpr(token.Plus, a, intNumber(1))),
+				b,
+			},
+		},
+		{
+			name:    "ThrowNewLine",
+			code:    "throw\na",
+			wantErr: E("tests.js:1:0: illegal newline after throw"),
+		},
+		{
+			name: "TryCatch",
+			code: "try { a } catch (e) { b }",
+			want: ast.NewTryStmt(blockStmt(a), e, blockStmt(b), nil),
+		},
+		{
+			name: "TryFinally",
+			code: "try { a } finally { b }",
+			want: ast.NewTryStmt(blockStmt(a), nil, nil, blockStmt(b)),
+		},
+		{
+			name: "TryCatchFinally",
+			code: "try {} catch (e) { a } finally { b }",
+			want: ast.NewTryStmt(blockStmt(), e, blockStmt(a), blockStmt(b)),
+		},
+		{
+			name:    "TryAlone",
+			code:    "try { a }",
+			wantErr: E("tests.js:1:0: missing catch or finally after try"),
+		},
+		{
+			name: "TryWithoutBlock",
+			code: "try a; catch (e) {}",
+			fail: true,
+		},
+		{
+			name: "CatchWithoutParam",
+			code: "try {} catch { a }",
+			fail: true,
+		},
+		{
+			name: "CatchWithoutBlock",
+			code: "try {} catch (e) a",
+			fail: true,
+		},
+	})
+}
+
+func TestLabeledStmt(t *testing.T) {
+	a, b, l, m := identifier("a"), identifier("b"), identifier("l"), identifier("m")
+
+	runTests(t, []TestCase{
+		{
+			name: "LabeledExpr",
+			code: "l: a",
+			want: ast.NewLabeledStmt(l, a),
+		},
+		{
+			name: "LabeledBlockBreak",
+			code: "l: { a; break l; b }",
+			want: ast.NewLabeledStmt(l, blockStmt(a, ast.NewBreakStmt(l), b)),
+		},
+		{
+			name: "LabeledLoopContinue",
+			code: "l: while (a) { while (b) continue l }",
+			want: ast.NewLabeledStmt(l, ast.NewWhileStmt(a,
+				blockStmt(ast.NewWhileStmt(b, ast.NewContinueStmt(l))),
+			)),
+		},
+		{
+			name: "ManyLabels",
+			code: "l: m: for (;;) continue l",
+			want: ast.NewLabeledStmt(l, ast.NewLabeledStmt(m,
+				ast.NewForStmt(nil, nil, nil, ast.NewContinueStmt(l)),
+			)),
+		},
+		{
+			name: "SiblingLabels",
+			code: "l: a; l: b",
+			wants: []ast.Node{
+				ast.NewLabeledStmt(l, a),
+				ast.NewLabeledStmt(l, b),
+			},
+		},
+		{
+			name: "BreakLabelNewLine",
+			code: "l: while (a) { break\nl }",
+			want: ast.NewLabeledStmt(l, ast.NewWhileStmt(a,
+				blockStmt(ast.NewBreakStmt(nil), l),
+			)),
+		},
+		{
+			name:    "UndefinedBreakLabel",
+			code:    "while (a) break l",
+			wantErr: E("tests.js:1:0: undefined label 'l'"),
+		},
+		{
+			name:    "UndefinedContinueLabel",
+			code:    "l: a; while (b) continue l",
+			wantErr: E("tests.js:1:0: undefined label 'l'"),
+		},
+		{
+			name: "ContinueNonIteration",
+			code: "while (a) { l: { continue l } }",
+			wantErr: E("tests.js:1:0: illegal continue statement: " +
+				"'l' does not denote an iteration statement"),
+		},
+		{
+			name:    "DuplicatedLabel",
+			code:    "l: { l: a }",
+			wantErr: E("tests.js:1:0: label 'l' has already been declared"),
+		},
+		{
+			name:    "LabelInsideFunction",
+			code:    "l: while (a) { function f() { break l } }",
+			wantErr: E("tests.js:1:0: undefined label 'l'"),
+		},
+		{
+			name: "LabelWithoutStmt",
+			code: "l:",
+			fail: true,
+		},
+	})
+}
+
+func TestBinaryExpr(t *testing.T) {
+	runTests(t, []TestCase{
+		{
+			name: "Sum",
+			code: "1 + 2",
+			want: binaryExpr(token.Plus, intNumber(1), intNumber(2)),
+		},
+		{
+			name: "SumWithoutSpaces",
+			code: "1+2",
+			want: binaryExpr(token.Plus, intNumber(1), intNumber(2)),
+		},
+		{
+			name: "SubIdentifiers",
+			code: "a-b",
+			want: binaryExpr(token.Minus, identifier("a"), identifier("b")),
+		},
+		{
+			name: "LeftAssociative",
+			code: "1 - 2 - 3", // same as: (1 - 2) - 3
+			want: binaryExpr(token.Minus,
+				binaryExpr(token.Minus, intNumber(1), intNumber(2)),
+				intNumber(3),
+			),
+		},
+		{
+			name: "MulHasPrecedence",
+			code: "1 + 2 * 3", // same as: 1 + (2 * 3)
+			want: binaryExpr(token.Plus,
+				intNumber(1),
+				binaryExpr(token.Mul, intNumber(2), intNumber(3)),
+			),
+		},
+		{
+			name: "QuoAndRemHavePrecedence",
+			code: "1 / 2 - 3 % 4",
+			want: binaryExpr(token.Minus,
+				binaryExpr(token.Quo, intNumber(1), intNumber(2)),
+				binaryExpr(token.Rem, intNumber(3), intNumber(4)),
+			),
+		},
+		{
+			name: "Parenthesis",
+			code: "(1 + 2) * 3",
+			want: binaryExpr(token.Mul,
+				binaryExpr(token.Plus, intNumber(1), intNumber(2)),
+				intNumber(3),
+			),
+		},
+		{
+			name: "UnaryOperands",
+			code: "-1 - -2",
+			want: binaryExpr(token.Minus,
+				ast.NewUnaryExpr(token.Minus, intNumber(1)),
+				ast.NewUnaryExpr(token.Minus, intNumber(2)),
+			),
+		},
+		{
+			name: "StringConcat",
+			code: `"a" + b.c + d()`,
+			want: binaryExpr(token.Plus,
+				binaryExpr(token.Plus,
+					str("a"),
+					memberExpr(identifier("b"), "c"),
+				),
+				callExpr(identifier("d"), []ast.Node{}),
+			),
+		},
+		{
+			name: "FuncallArgs",
+			code: "a(1 + 2, b * c)",
+			want: callExpr(identifier("a"), []ast.Node{
+				binaryExpr(token.Plus, intNumber(1), intNumber(2)),
+				binaryExpr(token.Mul, identifier("b"), identifier("c")),
+			}),
+		},
+		{
+			name: "VarInitializer",
+			code: "var a = 1 * 2;",
+			want: varDecls(varDecl(identifier("a"),
+				binaryExpr(token.Mul, intNumber(1), intNumber(2)),
+			)),
+		},
+		{
+			name: "SplitByNewline",
+			code: "1 + 2\n3 * 4",
+			wants: []ast.Node{
+				binaryExpr(token.Plus, intNumber(1), intNumber(2)),
+				binaryExpr(token.Mul, intNumber(3), intNumber(4)),
+			},
+		},
+		{
+			name:    "MissingRightOperand",
+			code:    "1 +",
+			wantErr: E("tests.js:1:0: unexpected EOF"),
+		},
+		{
+			name:    "MissingOperator",
+			code:    "1 2",
+			wantErr: E("tests.js:1:0: unexpected 2"),
+		},
+		{
+			name: "UnclosedParenthesis",
+			code: "(1 + 2",
+			fail: true,
+		},
+	})
+}
+
+func TestComparisonExpr(t *testing.T) {
+	runTests(t, []TestCase{
+		{
+			name: "Less",
+			code: "a < b",
+			want: binaryExpr(token.Less, identifier("a"), identifier("b")),
+		},
+		{
+			name: "GreaterEqWithoutSpaces",
+			code: "a>=1",
+			want: binaryExpr(token.GreaterEq, identifier("a"), intNumber(1)),
+		},
+		{
+			name: "AdditiveHasPrecedence",
+			code: "a + 1 <= b * 2",
+			want: binaryExpr(token.LessEq,
+				binaryExpr(token.Plus, identifier("a"), intNumber(1)),
+				binaryExpr(token.Mul, identifier("b"), intNumber(2)),
+			),
+		},
+		{
+			name: "RelationalHasPrecedence",
+			code: "a < b == c > d",
+			want: binaryExpr(token.Equal,
+				binaryExpr(token.Less, identifier("a"), identifier("b")),
+				binaryExpr(token.Greater, identifier("c"), identifier("d")),
+			),
+		},
+		{
+			name: "EqualityIsLeftAssociative",
+			code: "a === b !== c != d",
+			want: binaryExpr(token.NotEqual,
+				binaryExpr(token.NotTEqual,
+					binaryExpr(token.TEqual, identifier("a"), identifier("b")),
+					identifier("c"),
+				),
+				identifier("d"),
+			),
+		},
+		{
+			name: "InAndInstanceOf",
+			code: "a in b instanceof c < d",
+			want: binaryExpr(token.Less,
+				binaryExpr(token.InstanceOf,
+					binaryExpr(token.In, identifier("a"), identifier("b")),
+					identifier("c"),
+				),
+				identifier("d"),
+			),
+		},
+		{
+			name: "InHasLowerPrecedenceThanAdditive",
+			code: `"a" + b in c`,
+			want: binaryExpr(token.In,
+				binaryExpr(token.Plus, str("a"), identifier("b")),
+				identifier("c"),
+			),
+		},
+	})
+}
+
+func TestForInitNoIn(t *testing.T) {
+	a, b, c := identifier("a"), identifier("b"), identifier("c")
+
+	runTests(t, []TestCase{
+		{
+			name: "ForInIsNotInExpr",
+			code: "for (a in b) {}",
+			want: ast.NewForInStmt(a, b, blockStmt()),
+		},
+		{
+			name: "ParenthesizedIn",
+			code: "for (a = (b in c); a;) {}",
+			want: ast.NewForStmt(
+				assignExpr(token.Assign, a, binaryExpr(token.In, b, c)),
+				a, nil, blockStmt(),
+			),
+		},
+		{
+			name: "VarInitParenthesizedIn",
+			code: "for (var a = (b in c); a;) {}",
+			want: ast.NewForStmt(
+				varDecls(varDecl(a, binaryExpr(token.In, b, c))),
+				a, nil, blockStmt(),
+			),
+		},
+		{
+			name: "InsideCallArgsAndBrackets",
+			code: "for (a = c(b in c)[b in c]; a;) {}",
+			want: ast.NewForStmt(
+				assignExpr(token.Assign, a,
+					computedMemberExpr(
+						callExpr(c, []ast.Node{binaryExpr(token.In, b, c)}),
+						binaryExpr(token.In, b, c),
+					),
+				),
+				a, nil, blockStmt(),
+			),
+		},
+		{
+			name: "InInTestAndUpdate",
+			code: "for (; a in b; a = b in c) {}",
+			want: ast.NewForStmt(
+				nil,
+				binaryExpr(token.In, a, b),
+				assignExpr(token.Assign, a, binaryExpr(token.In, b, c)),
+				blockStmt(),
+			),
+		},
+		{
+			name: "InAfterFor",
+			code: "for (;;) {} a in b",
+			wants: []ast.Node{
+				ast.NewForStmt(nil, nil, nil, blockStmt()),
+				binaryExpr(token.In, a, b),
+			},
+		},
+		{
+			name: "UnparenthesizedInInit",
+			code: "for (var a = b in c; a;) {}",
+			fail: true,
+		},
+	})
+}
+
+func TestUnaryKeywordExpr(t *testing.T) {
+	a, b := identifier("a"), identifier("b")
+
+	runTests(t, []TestCase{
+		{
+			name: "TypeOf",
+			code: "typeof a",
+			want: ast.NewUnaryExpr(token.TypeOf, a),
+		},
+		{
+			name: "TypeOfTypeOf",
+			code: "typeof typeof a",
+			want: ast.NewUnaryExpr(token.TypeOf, ast.NewUnaryExpr(token.TypeOf, a)),
+		},
+		{
+			name: "Void",
+			code: "void 0",
+			want: ast.NewUnaryExpr(token.Void, intNumber(0)),
+		},
+		{
+			name: "Delete",
+			code: "delete a.b[a]",
+			want: ast.NewUnaryExpr(token.Delete,
+				computedMemberExpr(memberExpr(a, "b"), a)),
+		},
+		{
+			name: "Precedence",
+			code: `typeof a === "b"`,
+			want: binaryExpr(token.TEqual, ast.NewUnaryExpr(token.TypeOf, a), str("b")),
+		},
+		{
+			name: "Call",
+			code: "void a(b)",
+			want: ast.NewUnaryExpr(token.Void, callExpr(a, []ast.Node{b})),
+		},
+		{
+			name: "MissingOperand",
+			code: "typeof",
+			fail: true,
+		},
+	})
+}
+
+func TestUpdateExpr(t *testing.T) {
+	a, b := identifier("a"), identifier("b")
+
+	runTests(t, []TestCase{
+		{
+			name: "PostfixInc",
+			code: "a++",
+			want: ast.NewUpdateExpr(token.Inc, a, false),
+		},
+		{
+			name: "PostfixDec",
+			code: "a--",
+			want: ast.NewUpdateExpr(token.Dec, a, false),
+		},
+		{
+			name: "PrefixInc",
+			code: "++a",
+			want: ast.NewUpdateExpr(token.Inc, a, true),
+		},
+		{
+			name: "PrefixDec",
+			code: "--a.b",
+			want: ast.NewUpdateExpr(token.Dec, memberExpr(a, "b"), true),
+		},
+		{
+			name: "ComputedMember",
+			code: "a[b]++",
+			want: ast.NewUpdateExpr(token.Inc, computedMemberExpr(a, b), false),
+		},
+		{
+			name: "Precedence",
+			code: "a++ + ++b",
+			want: binaryExpr(token.Plus,
+				ast.NewUpdateExpr(token.Inc, a, false),
+				ast.NewUpdateExpr(token.Inc, b, true)),
+		},
+		{
+			name: "UnaryOperand",
+			code: "-a++",
+			want: ast.NewUnaryExpr(token.Minus,
+				ast.NewUpdateExpr(token.Inc, a, false)),
+		},
+		{
+			name: "NewLineBeforePostfix",
+			code: "a\n++b",
+			wants: []ast.Node{
+				a,
+				ast.NewUpdateExpr(token.Inc, b, true),
+			},
+		},
+		{
+			name: "NewLineAfterPrefix",
+			code: "++\na",
+			want: ast.NewUpdateExpr(token.Inc, a, true),
+		},
+		{
+			name:    "InvalidPostfixOperand",
+			code:    "1++",
+			wantErr: E("tests.js:1:0: Invalid left-hand side expression in postfix operation"),
+		},
+		{
+			name:    "InvalidPrefixOperand",
+			code:    `++"a"`,
+			wantErr: E("tests.js:1:0: Invalid left-hand side expression in prefix operation"),
+		},
+		{
+			name: "CallOperandIsRuntimeError",
+			code: "++f()",
+			want: ast.NewUpdateExpr(token.Inc, callExpr(identifier("f"), []ast.Node{}), true),
+		},
+		{
+			name: "MissingOperand",
+			code: "++",
+			fail: true,
+		},
+		{
+			name: "NewLineWithoutOperand",
+			code: "a\n++",
+			fail: true,
+		},
+	})
+}
+
+func TestDirectivePrologue(t *testing.T) {
+	for _, tc := range []struct {
+		code   string
+		strict bool
+	}{
+		{code: `"use strict"`, strict: true},
+		{code: `"use strict"; var a = 1`, strict: true},
+		{code: `"a"; "use strict"; var a = 1`, strict: true},
+		{code: `"a"
+"use strict"`, strict: true},
+		{code: ``, strict: false},
+		{code: `"a"`, strict: false},
+		{code: `var a; "use strict"`, strict: false},
+		{code: `("use strict")`, strict: false},
+		{code: `"use strict" + a`, strict: false},
+		{code: `"a" + b; "use strict"`, strict: false},
+		{code: `"use strict".length`, strict: false},
+		{code: `function f() { "use strict" }`, strict: false},
+	} {
+		tree, err := parser.Parse("tests.js", tc.code)
+		assert.NoError(t, err, "parsing %s", tc.code)
+
+		if tree.Strict != tc.strict {
+			t.Errorf("%s: expected strict %t but got %t", tc.code, tc.strict,
+				tree.Strict)
+		}
+	}
+}
+
+func TestStrictFunctions(t *testing.T) {
+	a := identifier("a")
+	anonymous := identifier("")
+	useStrict := str("use strict")
+
+	runTests(t, []TestCase{
+		{
+			name: "FunctionDirective",
+			code: `(function () { "use strict"; a })`,
+			want: funExpr(anonymous, []ast.Ident{}, strictProgram(useStrict, a)),
+		},
+		{
+			name: "NestedFunctionInheritsStrict",
+			code: `(function () { "use strict"; (function () {}) })`,
+			want: funExpr(anonymous, []ast.Ident{}, strictProgram(
+				useStrict,
+				funExpr(anonymous, []ast.Ident{}, strictProgram()),
+			)),
+		},
+		{
+			name: "StrictEndsWithFunction",
+			code: `(function () { "use strict" }); (function () {})`,
+			wants: []ast.Node{
+				funExpr(anonymous, []ast.Ident{}, strictProgram(useStrict)),
+				funExpr(anonymous, []ast.Ident{}, program()),
+			},
+		},
+		{
+			name: "DirectiveAfterStatement",
+			code: `(function () { a; "use strict" })`,
+			want: funExpr(anonymous, []ast.Ident{}, program(a, useStrict)),
+		},
+		{
+			name: "DuplicatedParamsNonStrict",
+			code: `(function (a, a) {})`,
+			want: funExpr(anonymous, []ast.Ident{a, a}, program()),
+		},
+		{
+			name: "EvalNonStrict",
+			code: `eval = arguments++`,
+			want: assignExpr(token.Assign, identifier("eval"),
+				ast.NewUpdateExpr(token.Inc, identifier("arguments"), false)),
+		},
+	})
+}
+
+func TestStrictModeErrors(t *testing.T) {
+	evalOrArguments := E("tests.js:1:0: Unexpected eval or arguments in strict mode")
+	duplicatedParam := E("tests.js:1:0: Duplicate parameter name not allowed in this context")
+
+	runTests(t, []TestCase{
+		{
+			name:    "With",
+			code:    `"use strict"; with (a) {}`,
+			wantErr: E("tests.js:1:0: Strict mode code may not include a with statement"),
+		},
+		{
+			name:    "DeleteIdentifier",
+			code:    `"use strict"; delete a`,
+			wantErr: E("tests.js:1:0: Delete of an unqualified identifier in strict mode."),
+		},
+		{
+			name:    "DuplicatedParams",
+			code:    `function f(a, b, a) { "use strict" }`,
+			wantErr: duplicatedParam,
+		},
+		{
+			name:    "DuplicatedParamsInStrictCode",
+			code:    `"use strict"; (function (a, a) {})`,
+			wantErr: duplicatedParam,
+		},
+		{
+			name:    "VarEval",
+			code:    `"use strict"; var eval`,
+			wantErr: evalOrArguments,
+		},
+		{
+			name:    "VarArguments",
+			code:    `function f() { "use strict"; var a, arguments = 1 }`,
+			wantErr: evalOrArguments,
+		},
+		{
+			name:    "AssignEval",
+			code:    `"use strict"; eval = 1`,
+			wantErr: evalOrArguments,
+		},
+		{
+			name:    "CompoundAssignArguments",
+			code:    `"use strict"; arguments += 1`,
+			wantErr: evalOrArguments,
+		},
+		{
+			name:    "PostfixEval",
+			code:    `"use strict"; eval++`,
+			wantErr: evalOrArguments,
+		},
+		{
+			name:    "PrefixArguments",
+			code:    `"use strict"; --arguments`,
+			wantErr: evalOrArguments,
+		},
+		{
+			name:    "ForInEval",
+			code:    `"use strict"; for (eval in a) {}`,
+			wantErr: evalOrArguments,
+		},
+		{
+			name:    "CatchArguments",
+			code:    `"use strict"; try {} catch (arguments) {}`,
+			wantErr: evalOrArguments,
+		},
+		{
+			name:    "FunctionNameEval",
+			code:    `function eval() { "use strict" }`,
+			wantErr: evalOrArguments,
+		},
+		{
+			name:    "FunExprNameArguments",
+			code:    `"use strict"; (function arguments() {})`,
+			wantErr: evalOrArguments,
+		},
+		{
+			name:    "ParamEval",
+			code:    `function f(a, eval) { "use strict" }`,
+			wantErr: evalOrArguments,
+		},
+	})
+}
+
+func TestLogicalExpr(t *testing.T) {
+	runTests(t, []TestCase{
+		{
+			name: "And",
+			code: "a && b",
+			want: binaryExpr(token.LAnd, identifier("a"), identifier("b")),
+		},
+		{
+			name: "AndHasPrecedenceOverOr",
+			code: "a || b && c", // same as: a || (b && c)
+			want: binaryExpr(token.LOr,
+				identifier("a"),
+				binaryExpr(token.LAnd, identifier("b"), identifier("c")),
+			),
+		},
+		{
+			name: "EqualityHasPrecedence",
+			code: "a == 1 && b < 2",
+			want: binaryExpr(token.LAnd,
+				binaryExpr(token.Equal, identifier("a"), intNumber(1)),
+				binaryExpr(token.Less, identifier("b"), intNumber(2)),
+			),
+		},
+		{
+			name: "Not",
+			code: "!a",
+			want: ast.NewUnaryExpr(token.LNot, identifier("a")),
+		},
+		{
+			name: "NotNot",
+			code: "!!a || !b",
+			want: binaryExpr(token.LOr,
+				ast.NewUnaryExpr(token.LNot,
+					ast.NewUnaryExpr(token.LNot, identifier("a"))),
+				ast.NewUnaryExpr(token.LNot, identifier("b")),
+			),
+		},
+	})
+}
+
+func TestBitwiseExpr(t *testing.T) {
+	runTests(t, []TestCase{
+		{
+			name: "OrZero",
+			code: "x | 0",
+			want: binaryExpr(token.Or, identifier("x"), intNumber(0)),
+		},
+		{
+			name: "ShiftZeroFill",
+			code: "x >>> 0",
+			want: binaryExpr(token.RShiftZero, identifier("x"), intNumber(0)),
+		},
+		{
+			name: "Precedences",
+			code: "a | b ^ c & d", // same as: a | (b ^ (c & d))
+			want: binaryExpr(token.Or,
+				identifier("a"),
+				binaryExpr(token.Xor,
+					identifier("b"),
+					binaryExpr(token.And, identifier("c"), identifier("d")),
+				),
+			),
+		},
+		{
+			name: "ShiftHasLowerPrecedenceThanAdditive",
+			code: "a << b + 1",
+			want: binaryExpr(token.LShift,
+				identifier("a"),
+				binaryExpr(token.Plus, identifier("b"), intNumber(1)),
+			),
+		},
+		{
+			name: "ShiftHasHigherPrecedenceThanRelational",
+			code: "a >> 1 < b",
+			want: binaryExpr(token.Less,
+				binaryExpr(token.RShift, identifier("a"), intNumber(1)),
+				identifier("b"),
+			),
+		},
+		{
+			name: "BitwiseHasLowerPrecedenceThanEquality",
+			code: "a & 1 == 1", // same as: a & (1 == 1)
+			want: binaryExpr(token.And,
+				identifier("a"),
+				binaryExpr(token.Equal, intNumber(1), intNumber(1)),
+			),
+		},
+		{
+			name: "BitwiseNot",
+			code: "~~a",
+			want: ast.NewUnaryExpr(token.Not,
+				ast.NewUnaryExpr(token.Not, identifier("a"))),
+		},
+	})
+}
+
+func TestObjectLiteral(t *testing.T) {
+	a, b, o, v := identifier("a"), identifier("b"), identifier("o"), identifier("v")
+	noargs := []ast.Ident{}
+
+	prop := func(name string, value ast.Node) ast.Property {
+		return ast.NewProperty(ast.PropInit, utf16.S(name), value)
+	}
+
+	objDecl := func(props ...ast.Property) ast.Node {
+		return varDecls(varDecl(o, ast.NewObjectLiteral(props)))
+	}
+
+	runTests(t, []TestCase{
+		{
+			name: "Empty",
+			code: "var o = {};",
+			want: objDecl(),
+		},
+		{
+			name: "EmptyBlockIsNotObject",
+			code: "{}",
+			want: blockStmt(),
+		},
+		{
+			name: "Grouping",
+			code: "({a: 1})",
+			want: ast.NewObjectLiteral([]ast.Property{prop("a", intNumber(1))}),
+		},
+		{
+			name: "Keys",
+			code: `var o = {a: 1, "b c": 2, 10: 3, 0x10: 4, 1.5: 5, if: 6, null: 7};`,
+			want: objDecl(
+				prop("a", intNumber(1)),
+				prop("b c", intNumber(2)),
+				prop("10", intNumber(3)),
+				prop("16", intNumber(4)),
+				prop("1.5", intNumber(5)),
+				prop("if", intNumber(6)),
+				prop("null", intNumber(7)),
+			),
+		},
+		{
+			name: "TrailingComma",
+			code: "var o = {a: b,};",
+			want: objDecl(prop("a", b)),
+		},
+		{
+			name: "Nested",
+			code: "var o = {a: {b: a = b}, b: a, c: b};",
+			want: objDecl(
+				prop("a", ast.NewObjectLiteral([]ast.Property{
+					prop("b", assignExpr(token.Assign, a, b)),
+				})),
+				prop("b", a),
+				prop("c", b),
+			),
+		},
+		{
+			name: "Accessors",
+			code: "var o = {get a() { return b }, set a(v) {}, get: 1, set: 2};",
+			want: objDecl(
+				ast.NewProperty(ast.PropGet, utf16.S("a"), funExpr(identifier(""), noargs,
+					program(ast.NewReturnStmt(b)))),
+				ast.NewProperty(ast.PropSet, utf16.S("a"), funExpr(identifier(""),
+					[]ast.Ident{v}, program())),
+				prop("get", intNumber(1)),
+				prop("set", intNumber(2)),
+			),
+		},
+		{
+			name: "DuplicatedData",
+			code: "var o = {a: 1, a: 2};",
+			want: objDecl(prop("a", intNumber(1)), prop("a", intNumber(2))),
+		},
+		{
+			name: "DuplicatedNumericKey",
+			code: "var o = {1: 1, get 1.0() {}};",
+			wantErr: E("tests.js:1:0: object literal may not have data and " +
+				"accessor property with the same name"),
+		},
+		{
+			name: "DataAndAccessor",
+			code: "var o = {get a() {}, a: 1};",
+			wantErr: E("tests.js:1:0: object literal may not have data and " +
+				"accessor property with the same name"),
+		},
+		{
+			name: "DuplicatedGetter",
+			code: "var o = {get a() {}, set a(v) {}, get a() {}};",
+			wantErr: E("tests.js:1:0: object literal may not have multiple " +
+				"get/set accessors with the same name"),
+		},
+		{
+			name:    "GetterWithParams",
+			code:    "var o = {get a(v) {}};",
+			wantErr: E("tests.js:1:0: getter must not have any formal parameters"),
+		},
+		{
+			name:    "SetterWithoutParams",
+			code:    "var o = {set a() {}};",
+			wantErr: E("tests.js:1:0: setter must have exactly one formal parameter"),
+		},
+		{
+			name: "MissingComma",
+			code: "var o = {a: 1 b: 2};",
+			fail: true,
+		},
+		{
+			name: "MissingValue",
+			code: "var o = {a};",
+			fail: true,
+		},
+		{
+			name: "Unclosed",
+			code: "var o = {a: 1",
+			fail: true,
+		},
+	})
+}
+
+func TestArrayLiteral(t *testing.T) {
+	a, b, o := identifier("a"), identifier("b"), identifier("o")
+
+	arrDecl := func(elems ...ast.Node) ast.Node {
+		return varDecls(varDecl(o, ast.NewArrayLiteral(elems)))
+	}
+
+	runTests(t, []TestCase{
+		{
+			name: "Empty",
+			code: "var o = [];",
+			want: arrDecl(),
+		},
+		{
+			name: "Elements",
+			code: `var o = [1, "b", a = b, a ? 1 : 2];`,
+			want: arrDecl(
+				intNumber(1),
+				str("b"),
+				assignExpr(token.Assign, a, b),
+				ast.NewConditionalExpr(a, intNumber(1), intNumber(2)),
+			),
+		},
+		{
+			name: "Holes",
+			code: "var o = [1, , 3];",
+			want: arrDecl(intNumber(1), nil, intNumber(3)),
+		},
+		{
+			name: "LeadingHoles",
+			code: "var o = [, , a];",
+			want: arrDecl(nil, nil, a),
+		},
+		{
+			name: "TrailingComma",
+			code: "var o = [a, b,];",
+			want: arrDecl(a, b),
+		},
+		{
+			name: "TrailingHole",
+			code: "var o = [a, ,];",
+			want: arrDecl(a, nil),
+		},
+		{
+			name: "OnlyHole",
+			code: "var o = [,];",
+			want: arrDecl(nil),
+		},
+		{
+			name: "Nested",
+			code: "var o = [[a], {a: [b]}];",
+			want: arrDecl(
+				ast.NewArrayLiteral([]ast.Node{a}),
+				ast.NewObjectLiteral([]ast.Property{
+					ast.NewProperty(ast.PropInit, utf16.S("a"),
+						ast.NewArrayLiteral([]ast.Node{b})),
+				}),
+			),
+		},
+		{
+			name: "Member",
+			code: "[a].length",
+			want: memberExpr(ast.NewArrayLiteral([]ast.Node{a}), "length"),
+		},
+		{
+			name: "MissingComma",
+			code: "var o = [a b];",
+			fail: true,
+		},
+		{
+			name: "Unclosed",
+			code: "var o = [a, b",
+			fail: true,
+		},
+	})
+}
+
+func TestConditionalExpr(t *testing.T) {
+	a, b, c, d, e := identifier("a"), identifier("b"), identifier("c"),
+		identifier("d"), identifier("e")
+
+	runTests(t, []TestCase{
+		{
+			name: "Simple",
+			code: "a ? b : c",
+			want: ast.NewConditionalExpr(a, b, c),
+		},
+		{
+			name: "RightAssociative",
+			code: "a ? b : c ? d : e", // same as: a ? b : (c ? d : e)
+			want: ast.NewConditionalExpr(a, b, ast.NewConditionalExpr(c, d, e)),
+		},
+		{
+			name: "Nested",
+			code: "a ? b ? c : d : e",
+			want: ast.NewConditionalExpr(a, ast.NewConditionalExpr(b, c, d), e),
+		},
+		{
+			name: "Precedence",
+			code: "a || b ? c + 1 : d = e",
+			want: ast.NewConditionalExpr(
+				binaryExpr(token.LOr, a, b),
+				binaryExpr(token.Plus, c, intNumber(1)),
+				assignExpr(token.Assign, d, e),
+			),
+		},
+		{
+			name: "Assignment",
+			code: "a = b ? c : d",
+			want: assignExpr(token.Assign, a, ast.NewConditionalExpr(b, c, d)),
+		},
+		{
+			name: "MissingColon",
+			code: "a ? b",
+			fail: true,
+		},
+	})
+}
+
+func TestSequenceExpr(t *testing.T) {
+	a, b, c := identifier("a"), identifier("b"), identifier("c")
+
+	runTests(t, []TestCase{
+		{
+			name: "Simple",
+			code: "a, b, c",
+			want: ast.NewSequenceExpr([]ast.Node{a, b, c}),
+		},
+		{
+			name: "Assignments",
+			code: "a = 1, b = 2",
+			want: ast.NewSequenceExpr([]ast.Node{
+				assignExpr(token.Assign, a, intNumber(1)),
+				assignExpr(token.Assign, b, intNumber(2)),
+			}),
+		},
+		{
+			name: "Grouping",
+			code: "(a, b) * c",
+			want: binaryExpr(token.Mul, ast.NewSequenceExpr([]ast.Node{a, b}), c),
+		},
+		{
+			name: "CallArgs",
+			code: "a(b, (b, c))",
+			want: callExpr(a, []ast.Node{b, ast.NewSequenceExpr([]ast.Node{b, c})}),
+		},
+		{
+			name: "VarDecls",
+			code: "var a = b, c;",
+			want: varDecls(varDecl(a, b), varDecl(c, nil)),
+		},
+		{
+			name: "ForSequences",
+			code: "for (a = 0, b = 0; a; a += 1, b += 1) {}",
+			want: ast.NewForStmt(
+				ast.NewSequenceExpr([]ast.Node{
+					assignExpr(token.Assign, a, intNumber(0)),
+					assignExpr(token.Assign, b, intNumber(0)),
+				}),
+				a,
+				ast.NewSequenceExpr([]ast.Node{
+					assignExpr(token.AddAssign, a, intNumber(1)),
+					assignExpr(token.AddAssign, b, intNumber(1)),
+				}),
+				blockStmt(),
+			),
+		},
+		{
+			name: "TrailingComma",
+			code: "a, b,",
+			fail: true,
+		},
+	})
+}
+
+func TestAssignExpr(t *testing.T) {
+	runTests(t, []TestCase{
+		{
+			name: "Simple",
+			code: "a = 1",
+			want: assignExpr(token.Assign, identifier("a"), intNumber(1)),
+		},
+		{
+			name: "RightAssociative",
+			code: "a = b = 3", // same as: a = (b = 3)
+			want: assignExpr(token.Assign,
+				identifier("a"),
+				assignExpr(token.Assign, identifier("b"), intNumber(3)),
+			),
+		},
+		{
+			name: "MemberTarget",
+			code: "console.x.y = a",
+			want: assignExpr(token.Assign,
+				memberExpr(memberExpr(identifier("console"), "x"), "y"),
+				identifier("a"),
+			),
+		},
+		{
+			name: "Compound",
+			code: "a += b -= 2",
+			want: assignExpr(token.AddAssign,
+				identifier("a"),
+				assignExpr(token.SubAssign, identifier("b"), intNumber(2)),
+			),
+		},
+		{
+			name: "ValueIsBinaryExpr",
+			code: "a >>>= b | 1",
+			want: assignExpr(token.RShiftZeroAssign,
+				identifier("a"),
+				binaryExpr(token.Or, identifier("b"), intNumber(1)),
+			),
+		},
+		{
+			// validated at runtime
+			name:    "InvalidTarget",
+			code:    "1 = 2",
+			wantErr: E("tests.js:1:0: Invalid left-hand side in assignment"),
+		},
+		{
+			name:    "InvalidCompoundTarget",
+			code:    `"a" += 1`,
+			wantErr: E("tests.js:1:0: Invalid left-hand side in assignment"),
+		},
+		{
+			name:    "BinaryExprTarget",
+			code:    "a + 1 = 2",
+			wantErr: E("tests.js:1:0: Invalid left-hand side in assignment"),
+		},
+		{
+			name: "CallTargetIsRuntimeError",
+			code: "f() = 1",
+			want: assignExpr(token.Assign, callExpr(identifier("f"), []ast.Node{}), intNumber(1)),
+		},
+		{
+			name: "VarInitializer",
+			code: "var a = b = 1",
+			want: varDecls(varDecl(identifier("a"),
+				assignExpr(token.Assign, identifier("b"), intNumber(1)))),
+		},
+		{
+			name: "FuncallArgs",
+			code: "console.log(a = 1, b *= 2)",
+			want: callExpr(memberExpr(identifier("console"), "log"), []ast.Node{
+				assignExpr(token.Assign, identifier("a"), intNumber(1)),
+				assignExpr(token.MulAssign, identifier("b"), intNumber(2)),
+			}),
+		},
+		{
+			name: "MissingValue",
+			code: "a =",
+			fail: true,
+		},
+	})
+}
+
+// TestCase is the description of an parser related test.
+// The fields want and wants are mutually exclusive, you should
+// never provide both. If "wants" is provided the "want" field will be ignored.
+//
+// This is supposed to make it easier to test single nodes and multiple nodes.
+type TestCase struct {
+	name    string
+	code    string
+	want    ast.Node
+	wants   []ast.Node
+	fail    bool
+	wantErr error
+}
+
+func (tc *TestCase) run(t *testing.T) {
+	t.Run(tc.name, func(t *testing.T) {
+		tree, err := parser.Parse("tests.js", tc.code)
+
+		if tc.fail && tc.wantErr == nil {
+			if err == nil {
+				t.Fatalf("expected an error got succeess:\n%s\n", tree)
+			}
+		} else {
+			assert.EqualErrs(t, tc.wantErr, err, "parser err")
+		}
+
+		if err != nil {
+			return
+		}
+
+		if tc.wants == nil {
+			assertEqualNodes(t, []ast.Node{tc.want}, tree.Nodes)
+			return
+		}
+
+		assertEqualNodes(t, tc.wants, tree.Nodes)
+	})
+}
+
+func runTests(t *testing.T, tcases []TestCase) {
+	for _, tcase := range tcases {
+		tcase.run(t)
+	}
+}
+
+func assertEqualNodes(t *testing.T, want []ast.Node, got []ast.Node) {
+	if len(want) != len(got) {
+		t.Errorf("want[%d] nodes but got[%d] nodes", len(want), len(got))
+		t.Fatalf("want:\n%v\n\ngot:\n%v\n", want, got)
+	}
+
+	for i, w := range want {
+		g := got[i]
+		if !w.Equal(g) {
+			t.Errorf("wanted node[%d][%v] != got node[%d][%v]", i, w, i, g)
+		}
+	}
+}
+
+func number(n float64) ast.Number {
+	return ast.NewNumber(n)
+}
+
+func intNumber(n int64) ast.Number {
+	return ast.NewIntNumber(n)
+}
+
+func identifier(val string) ast.Ident {
+	return ast.NewIdent(utf16.S(val))
+}
+
+func str(val string) ast.String {
+	return ast.NewString(utf16.S(val))
+}
+
+func null() ast.Null {
+	return ast.NewNull()
+}
+
+func undefined() ast.Undefined {
+	return ast.NewUndefined()
+}
+
+func boolean(b bool) ast.Bool {
+	return ast.NewBool(b)
+}
+
+func memberExpr(obj ast.Node, memberName string) *ast.MemberExpr {
+	return ast.NewMemberExpr(obj, identifier(memberName))
+}
+
+func computedMemberExpr(obj ast.Node, property ast.Node) *ast.MemberExpr {
+	return ast.NewComputedMemberExpr(obj, property)
+}
+
+func binaryExpr(op token.Type, left, right ast.Node) *ast.BinaryExpr {
+	return ast.NewBinaryExpr(op, left, right)
+}
+
+func assignExpr(op token.Type, target, value ast.Node) *ast.AssignExpr {
+	return ast.NewAssignExpr(op, target, value)
+}
+
+func callExpr(callee ast.Node, args []ast.Node) *ast.CallExpr {
+	return ast.NewCallExpr(callee, args)
+}
+
+func fundecl(name ast.Ident, args []ast.Ident, body *ast.Program) *ast.FunDecl {
 	return ast.NewFunDecl(name, args, body)
 }
 
+func funExpr(name ast.Ident, args []ast.Ident, body *ast.Program) *ast.FunExpr {
+	return ast.NewFunExpr(name, args, body)
+}
+
+func blockStmt(stmts ...ast.Node) *ast.BlockStmt {
+	return ast.NewBlockStmt(stmts)
+}
+
+func ifStmt(cond, then, els ast.Node) *ast.IfStmt {
+	return ast.NewIfStmt(cond, then, els)
+}
+
 func program(stmts ...ast.Node) *ast.Program {
 	return &ast.Program{
 		Nodes: stmts,
 	}
 }
 
+func strictProgram(stmts ...ast.Node) *ast.Program {
+	return &ast.Program{
+		Nodes:  stmts,
+		Strict: true,
+	}
+}
+
 func varDecls(vars ...ast.VarDecl) ast.VarDecls {
 	return ast.NewVarDecls(vars...)
 }
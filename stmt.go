@@ -0,0 +1,561 @@
+package abad
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/NeowayLabs/abad/ast"
+	"github.com/NeowayLabs/abad/envrec"
+	"github.com/NeowayLabs/abad/internal/utf16"
+	"github.com/NeowayLabs/abad/types"
+)
+
+type (
+	completionType int
+
+	// completion is the result of the execution of statements.
+	// Throw completions are not represented here, exceptions are
+	// the errors returned together with the completion (see
+	// types.Exception).
+	// http://es5.github.io/#x8.9
+	completion struct {
+		typ    completionType
+		value  types.Value // nil when empty
+		target utf16.Str   // label of break and continue, nil when empty
+	}
+
+	// labelSet is the set of labels of a statement.
+	// http://es5.github.io/#x12.12
+	labelSet []utf16.Str
+)
+
+const (
+	completionNormal completionType = iota
+	completionBreak
+	completionContinue
+	completionReturn
+)
+
+// execStmt executes the statement n.
+// http://es5.github.io/#x12
+func (a *Abad) execStmt(n ast.Node) (completion, error) {
+	if ast.IsExpr(n) {
+		val, err := a.evalExpr(n)
+		return completion{value: val}, err
+	}
+
+	switch n.Type() {
+	case ast.NodeVarDecls:
+		return completion{}, a.evalVarDecls(n.(ast.VarDecls))
+	case ast.NodeFunDecl:
+		// already instantiated when entering the code
+		return completion{}, nil
+	case ast.NodeReturnStmt:
+		return a.execReturnStmt(n.(*ast.ReturnStmt))
+	case ast.NodeBlockStmt:
+		return a.execBlockStmt(n.(*ast.BlockStmt))
+	case ast.NodeIfStmt:
+		return a.execIfStmt(n.(*ast.IfStmt))
+	case ast.NodeWhileStmt:
+		return a.execWhileStmt(n.(*ast.WhileStmt), nil)
+	case ast.NodeDoWhileStmt:
+		return a.execDoWhileStmt(n.(*ast.DoWhileStmt), nil)
+	case ast.NodeForStmt:
+		return a.execForStmt(n.(*ast.ForStmt), nil)
+	case ast.NodeForInStmt:
+		return a.execForInStmt(n.(*ast.ForInStmt), nil)
+	case ast.NodeSwitchStmt:
+		return a.execSwitchStmt(n.(*ast.SwitchStmt))
+	case ast.NodeThrowStmt:
+		return a.execThrowStmt(n.(*ast.ThrowStmt))
+	case ast.NodeTryStmt:
+		return a.execTryStmt(n.(*ast.TryStmt))
+	case ast.NodeLabeledStmt:
+		return a.execLabeledStmt(n.(*ast.LabeledStmt))
+	case ast.NodeBreakStmt:
+		return completion{
+			typ:    completionBreak,
+			target: utf16.Str(n.(ast.BreakStmt).Label),
+		}, nil
+	case ast.NodeContinueStmt:
+		return completion{
+			typ:    completionContinue,
+			target: utf16.Str(n.(ast.ContinueStmt).Label),
+		}, nil
+	}
+
+	panic(fmt.Sprintf("AST(%s) not implemented", n))
+}
+
+// execStmts executes the statements until one of them completes
+// abruptly. The value of the completion is the value of the last
+// statement that produced a value.
+// http://es5.github.io/#x12.1
+func (a *Abad) execStmts(stmts []ast.Node) (completion, error) {
+	var value types.Value
+
+	for _, stmt := range stmts {
+		c, err := a.execStmt(stmt)
+		if err != nil {
+			return completion{}, err
+		}
+
+		if c.value != nil {
+			value = c.value
+		}
+
+		if c.typ != completionNormal {
+			c.value = value
+			return c, nil
+		}
+	}
+
+	return completion{value: value}, nil
+}
+
+// execBlockStmt executes the statements of block. Function declarations
+// are not allowed inside blocks by ES5, but every implementation accepts
+// them: like V8, they are bound when the block is entered.
+// http://es5.github.io/#x12.1
+func (a *Abad) execBlockStmt(block *ast.BlockStmt) (completion, error) {
+	err := a.declareFunctions(block.Nodes)
+	if err != nil {
+		return completion{}, err
+	}
+
+	return a.execStmts(block.Nodes)
+}
+
+// http://es5.github.io/#x12.5
+func (a *Abad) execIfStmt(stmt *ast.IfStmt) (completion, error) {
+	cond, err := a.evalExpr(stmt.Cond)
+	if err != nil {
+		return completion{}, err
+	}
+
+	if cond.ToBool() {
+		return a.execStmt(stmt.Then)
+	}
+
+	if stmt.Else != nil {
+		return a.execStmt(stmt.Else)
+	}
+
+	return completion{}, nil
+}
+
+// http://es5.github.io/#x12.6.1
+func (a *Abad) execDoWhileStmt(
+	stmt *ast.DoWhileStmt, labels labelSet,
+) (completion, error) {
+	var value types.Value
+
+	for {
+		c, err := a.execStmt(stmt.Body)
+		if err != nil {
+			return completion{}, err
+		}
+
+		if c.value != nil {
+			value = c.value
+		}
+
+		if exit, res := exitLoop(c, value, labels); exit {
+			return res, nil
+		}
+
+		cond, err := a.evalExpr(stmt.Cond)
+		if err != nil {
+			return completion{}, err
+		}
+
+		if !cond.ToBool() {
+			return completion{value: value}, nil
+		}
+	}
+}
+
+// http://es5.github.io/#x12.6.2
+func (a *Abad) execWhileStmt(
+	stmt *ast.WhileStmt, labels labelSet,
+) (completion, error) {
+	return a.loop(stmt.Cond, nil, stmt.Body, labels)
+}
+
+// http://es5.github.io/#x12.6.3
+func (a *Abad) execForStmt(
+	stmt *ast.ForStmt, labels labelSet,
+) (completion, error) {
+	if stmt.Init != nil {
+		_, err := a.execStmt(stmt.Init)
+		if err != nil {
+			return completion{}, err
+		}
+	}
+
+	return a.loop(stmt.Cond, stmt.Post, stmt.Body, labels)
+}
+
+// execForInStmt enumerates the names of the enumerable properties
+// of the object. The names are collected before the first iteration,
+// properties added by the body are not visited and the deleted ones
+// are skipped.
+// http://es5.github.io/#x12.6.4
+func (a *Abad) execForInStmt(
+	stmt *ast.ForInStmt, labels labelSet,
+) (completion, error) {
+	target := stmt.Target
+	if decls, ok := target.(ast.VarDecls); ok {
+		err := a.evalVarDecls(decls)
+		if err != nil {
+			return completion{}, err
+		}
+
+		target = decls[0].Name
+	}
+
+	val, err := a.evalExpr(stmt.Object)
+	if err != nil {
+		return completion{}, err
+	}
+
+	var (
+		value types.Value
+		names []utf16.Str
+		obj   types.Object
+	)
+
+	switch val.Kind() {
+	case types.KindUndefined, types.KindNull:
+		return completion{}, nil
+	case types.KindObject:
+		obj = val.(types.Object)
+		names = obj.Enumerate()
+	case types.KindString:
+		// the indexes of the wrapper String object
+		for i := 0; i < val.(types.String).Length(); i++ {
+			names = append(names, utf16.S(strconv.Itoa(i)))
+		}
+	}
+
+	for _, name := range names {
+		if obj != nil && !obj.HasProperty(name) {
+			continue
+		}
+
+		ref, err := a.evalRef(target, errInvalidAssignTarget)
+		if err != nil {
+			return completion{}, err
+		}
+
+		err = a.putValue(ref, types.String(name))
+		if err != nil {
+			return completion{}, err
+		}
+
+		c, err := a.execStmt(stmt.Body)
+		if err != nil {
+			return completion{}, err
+		}
+
+		if c.value != nil {
+			value = c.value
+		}
+
+		if exit, res := exitLoop(c, value, labels); exit {
+			return res, nil
+		}
+	}
+
+	return completion{value: value}, nil
+}
+
+// loop executes body while cond (if any) is true, evaluating
+// post (if any) after each iteration.
+func (a *Abad) loop(
+	cond, post, body ast.Node, labels labelSet,
+) (completion, error) {
+	var value types.Value
+
+	for {
+		if cond != nil {
+			val, err := a.evalExpr(cond)
+			if err != nil {
+				return completion{}, err
+			}
+
+			if !val.ToBool() {
+				return completion{value: value}, nil
+			}
+		}
+
+		c, err := a.execStmt(body)
+		if err != nil {
+			return completion{}, err
+		}
+
+		if c.value != nil {
+			value = c.value
+		}
+
+		if exit, res := exitLoop(c, value, labels); exit {
+			return res, nil
+		}
+
+		if post != nil {
+			_, err := a.evalExpr(post)
+			if err != nil {
+				return completion{}, err
+			}
+		}
+	}
+}
+
+// exitLoop tells if the completion c of the body of a loop
+// terminates it and, in this case, the completion of the loop.
+// The value is the value of the loop so far and labels is the
+// label set of the loop.
+func exitLoop(c completion, value types.Value, labels labelSet) (bool, completion) {
+	switch c.typ {
+	case completionNormal:
+		return false, completion{}
+	case completionContinue:
+		if c.target == nil || labels.has(c.target) {
+			return false, completion{}
+		}
+	case completionBreak:
+		if c.target == nil || labels.has(c.target) {
+			return true, completion{value: value}
+		}
+	}
+
+	return true, c
+}
+
+// execSwitchStmt executes the clauses starting at the first case
+// strictly equal to the discriminant, or at the default clause if
+// none matches, until a break. Like blocks, function declarations
+// are bound when entering the switch.
+// http://es5.github.io/#x12.11
+func (a *Abad) execSwitchStmt(stmt *ast.SwitchStmt) (completion, error) {
+	input, err := a.evalExpr(stmt.Discriminant)
+	if err != nil {
+		return completion{}, err
+	}
+
+	for _, clause := range stmt.Cases {
+		err := a.declareFunctions(clause.Body)
+		if err != nil {
+			return completion{}, err
+		}
+	}
+
+	start := -1
+	for i, clause := range stmt.Cases {
+		if clause.Test == nil {
+			continue
+		}
+
+		val, err := a.evalExpr(clause.Test)
+		if err != nil {
+			return completion{}, err
+		}
+
+		if types.StrictEqual(input, val) {
+			start = i
+			break
+		}
+	}
+
+	if start < 0 {
+		start = stmt.Default()
+		if start < 0 {
+			return completion{}, nil
+		}
+	}
+
+	var value types.Value
+
+	for _, clause := range stmt.Cases[start:] {
+		c, err := a.execStmts(clause.Body)
+		if err != nil {
+			return completion{}, err
+		}
+
+		if c.value != nil {
+			value = c.value
+		}
+
+		if c.typ == completionBreak && c.target == nil {
+			return completion{value: value}, nil
+		}
+
+		if c.typ != completionNormal {
+			c.value = value
+			return c, nil
+		}
+	}
+
+	return completion{value: value}, nil
+}
+
+// http://es5.github.io/#x12.13
+func (a *Abad) execThrowStmt(stmt *ast.ThrowStmt) (completion, error) {
+	val, err := a.evalExpr(stmt.Value)
+	if err != nil {
+		return completion{}, err
+	}
+
+	return completion{}, types.NewThrownValue(val)
+}
+
+// execTryStmt executes the try statement. Only the errors that are
+// ecmascript exceptions are caught, the others (eg.: internal errors)
+// abort the execution. The finally block replaces the completion
+// of the statement when it completes abruptly.
+// http://es5.github.io/#x12.14
+func (a *Abad) execTryStmt(stmt *ast.TryStmt) (completion, error) {
+	c, err := a.execBlockStmt(stmt.Body)
+
+	if exc, ok := err.(types.Exception); ok && stmt.Catch != nil {
+		c, err = a.execCatch(stmt, exc.Value())
+	}
+
+	if stmt.Finally == nil {
+		return c, err
+	}
+
+	if _, ok := err.(types.Exception); err != nil && !ok {
+		return completion{}, err
+	}
+
+	f, ferr := a.execBlockStmt(stmt.Finally)
+	if ferr != nil || f.typ != completionNormal {
+		return f, ferr
+	}
+
+	return c, err
+}
+
+// execCatch executes the catch block of stmt with the parameter
+// bound to the exception in a new environment.
+// http://es5.github.io/#x12.14
+func (a *Abad) execCatch(stmt *ast.TryStmt, exc types.Value) (completion, error) {
+	env := envrec.NewDeclLexEnv(a.ctx.lexEnv)
+	rec := env.Rec()
+	param := utf16.Str(stmt.Param)
+
+	err := rec.New(param, false)
+	if err != nil {
+		return completion{}, err
+	}
+
+	err = rec.Set(param, exc, false)
+	if err != nil {
+		return completion{}, err
+	}
+
+	saved := a.ctx.lexEnv
+	a.ctx.lexEnv = env
+	defer func() { a.ctx.lexEnv = saved }()
+
+	return a.execBlockStmt(stmt.Catch)
+}
+
+// execLabeledStmt executes the statement with the label set made of
+// the consecutive labels. Only iteration statements make use of it,
+// the breaks targeting the labels complete normally.
+// http://es5.github.io/#x12.12
+func (a *Abad) execLabeledStmt(stmt *ast.LabeledStmt) (completion, error) {
+	var (
+		labels labelSet
+		body   ast.Node = stmt
+	)
+
+	for body.Type() == ast.NodeLabeledStmt {
+		labeled := body.(*ast.LabeledStmt)
+		labels = append(labels, utf16.Str(labeled.Label))
+		body = labeled.Body
+	}
+
+	var (
+		c   completion
+		err error
+	)
+
+	switch body.Type() {
+	case ast.NodeWhileStmt:
+		c, err = a.execWhileStmt(body.(*ast.WhileStmt), labels)
+	case ast.NodeDoWhileStmt:
+		c, err = a.execDoWhileStmt(body.(*ast.DoWhileStmt), labels)
+	case ast.NodeForStmt:
+		c, err = a.execForStmt(body.(*ast.ForStmt), labels)
+	case ast.NodeForInStmt:
+		c, err = a.execForInStmt(body.(*ast.ForInStmt), labels)
+	default:
+		c, err = a.execStmt(body)
+	}
+
+	if err != nil {
+		return completion{}, err
+	}
+
+	if c.typ == completionBreak && labels.has(c.target) {
+		return completion{value: c.value}, nil
+	}
+
+	return c, nil
+}
+
+func (labels labelSet) has(label utf16.Str) bool {
+	for _, l := range labels {
+		if l.Equal(label) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// http://es5.github.io/#x12.9
+func (a *Abad) execReturnStmt(stmt *ast.ReturnStmt) (completion, error) {
+	c := completion{
+		typ:   completionReturn,
+		value: types.Undefined,
+	}
+
+	if stmt.Value == nil {
+		return c, nil
+	}
+
+	val, err := a.evalExpr(stmt.Value)
+	if err != nil {
+		return completion{}, err
+	}
+
+	c.value = val
+	return c, nil
+}
+
+// evalVarDecls assigns the initializers of the variables,
+// the bindings were created when entering the code.
+// http://es5.github.io/#x12.2
+func (a *Abad) evalVarDecls(decls ast.VarDecls) error {
+	for _, decl := range decls {
+		if decl.Value == nil {
+			continue
+		}
+
+		val, err := a.evalExpr(decl.Value)
+		if err != nil {
+			return err
+		}
+
+		err = a.putValue(a.identRef(decl.Name), val)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
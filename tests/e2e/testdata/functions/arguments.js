@@ -0,0 +1,53 @@
+function f(a, b) {
+	console.log(arguments.length, arguments[0], arguments[1], arguments[2])
+	a = 10
+	arguments[1] = 20
+	console.log(a, b, arguments[0], arguments[1])
+	return arguments
+}
+var args = f(1, 2, 3)
+console.log(args.length, "" + args, typeof args)
+
+function g(a, b) {
+	arguments[1] = 5
+	b = 6
+	return "" + b + arguments[1] + arguments.length
+}
+console.log(g(1))
+
+function h(a) {
+	delete arguments[0]
+	arguments[0] = 2
+	return "" + a + arguments[0]
+}
+console.log(h(1))
+
+function callee() { return arguments.callee === callee }
+console.log(callee())
+
+function dup(a, a) { return "" + a + arguments[0] + arguments[1] }
+console.log(dup(1, 2))
+
+function shadow(arguments) { return arguments }
+console.log(shadow(7))
+
+function shadowFn() { function arguments() {} return typeof arguments }
+console.log(shadowFn())
+
+function shadowVar() { var arguments; return typeof arguments }
+console.log(shadowVar())
+
+function inc(a) { arguments[0]++; return a }
+console.log(inc(1))
+
+function keys(a, b) { var k = ""; for (var i in arguments) { k += i } return k }
+console.log(keys(1, 2, 3))
+
+function sum() {
+	var total = 0
+	for (var i = 0; i < arguments.length; i++) {
+		total += arguments[i]
+	}
+	return total
+}
+console.log(sum(1, 2, 3, 4))
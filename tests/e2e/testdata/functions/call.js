@@ -0,0 +1,39 @@
+function add(a, b) {
+	return a + b
+}
+console.log(add(1, 2), add(1), add(1, 2, 3))
+
+function fib(n) {
+	return n < 2 && n || n > 1 && fib(n - 1) + fib(n - 2) || 0
+}
+console.log(fib(0), fib(1), fib(10), fib(20))
+
+console.log(early(3))
+function early(x) { return x * 2 }
+
+function noret() { var x = 1 }
+console.log(noret())
+
+function counter() {
+	var count = 0
+	function inc() {
+		count += 1
+		return count
+	}
+	return inc
+}
+var c1 = counter()
+var c2 = counter()
+c1()
+c1()
+console.log(c1(), c2())
+
+var x = "global"
+function shadow(x) { return x }
+console.log(shadow("local"), x)
+function setsGlobal() { y = 1; var z = 2 }
+setsGlobal()
+console.log(y)
+function empty() { return }
+console.log(empty())
+console.log(add.length)
@@ -0,0 +1,34 @@
+function Point() {}
+Point.prototype.x = 0
+Point.prototype.y = 0
+Point.prototype.describe = function () {
+	return "point"
+}
+
+var p = new Point()
+var q = new Point
+console.log(p.x, q.y, p.describe())
+console.log(p.constructor === Point, p === q)
+
+p.x = 10
+console.log(p.x, q.x, Point.prototype.x)
+
+function Config(name) {
+	return {name: name, enabled: true}
+}
+var cfg = new Config("abad")
+console.log(cfg.name, cfg.enabled, cfg.constructor === Config)
+
+function Primitive() {
+	return 42
+}
+console.log(new Primitive().constructor === Primitive)
+
+var list = new Array(1, 2, 3)
+console.log(list.length, "" + list, "" + new Array(2))
+
+try {
+	new console.log("nope")
+} catch (e) {
+	console.log(e.name)
+}
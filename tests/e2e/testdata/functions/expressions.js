@@ -0,0 +1,24 @@
+var add = function(a, b) { return a + b }
+console.log(add(2, 3))
+
+function apply(fn, x) { return fn(x) }
+console.log(apply(function(n) { return n * n }, 7))
+
+console.log((function() { return "iife" })())
+console.log(function(x) { return x + 1 }(41))
+var r = (function(a) { return a * 2 }(21))
+console.log(r)
+
+var fact = function f(n) { return n < 2 && 1 || n * f(n - 1) }
+console.log(fact(5))
+
+var g = function self() { self = 1; return self === g }
+console.log(g())
+
+var h = function inner() { return typeofInner }
+var typeofInner = "outer"
+console.log(h())
+
+function makeAdder(x) { return function(y) { return x + y } }
+var add10 = makeAdder(10)
+console.log(add10(5))
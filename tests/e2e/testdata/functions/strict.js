@@ -0,0 +1,81 @@
+function sloppy() { return this === undefined }
+function strict() { "use strict"; return this === undefined }
+console.log(sloppy(), strict())
+
+function nested() {
+	"use strict"
+	return (function () { return this })()
+}
+console.log(nested())
+
+function undeclared() {
+	"use strict"
+	try {
+		notDeclared = 1
+	} catch (e) {
+		return e.name
+	}
+}
+console.log(undeclared(), typeof notDeclared)
+
+function readOnly() {
+	"use strict"
+	var list = [1, 2]
+	try {
+		Array.prototype = {}
+	} catch (e) {
+		return e.name
+	}
+}
+console.log(readOnly())
+
+function nonDeletable() {
+	"use strict"
+	try {
+		delete [].length
+	} catch (e) {
+		return e.name
+	}
+}
+console.log(nonDeletable())
+
+function strictArgs(a) {
+	"use strict"
+	a = 2
+	arguments[0] = 3
+	return "" + a + arguments[0]
+}
+console.log(strictArgs(1))
+
+function callee() {
+	"use strict"
+	try {
+		return arguments.callee
+	} catch (e) {
+		return e.name
+	}
+}
+console.log(callee())
+
+function notDirective() {
+	"a" + "use strict"
+	return this === undefined
+}
+console.log(notDirective())
+
+function afterDirective() {
+	"other"
+	"use strict"
+	return this === undefined
+}
+console.log(afterDirective())
+
+function primitive() {
+	"use strict"
+	try {
+		"abc".x = 1
+	} catch (e) {
+		return e.name
+	}
+}
+console.log(primitive())
@@ -0,0 +1,40 @@
+var counter = {
+	count: 0,
+	inc: function () {
+		this.count += 1
+		return this
+	},
+}
+counter.inc().inc().inc()
+console.log(counter.count)
+
+function Point(x, y) {
+	this.x = x
+	this.y = y
+}
+Point.prototype.sum = function () {
+	return this.x + this.y
+}
+var p = new Point(1, 2)
+console.log(p.x, p.y, p.sum(), p["sum"]())
+
+var rect = {
+	w: 2,
+	h: 3,
+	get area() {
+		return this.w * this.h
+	},
+}
+console.log(rect.area)
+
+var methods = [function () {
+	return this.length
+}]
+console.log(methods[0]())
+
+console.log([1, 2, 3].join("+"), [4, [5, 6]].toString())
+
+function self() {
+	return this
+}
+console.log(self() === this, this.console === console)
@@ -0,0 +1,31 @@
+var empty = []
+console.log(empty.length, "" + empty)
+
+var numbers = [1, 2, 3,]
+console.log(numbers.length, "" + numbers)
+
+var holes = [1, , 3, ,]
+console.log(holes.length, "" + holes)
+
+var indexes = ""
+for (var i in holes) {
+	indexes += i + " "
+}
+console.log(indexes)
+
+var nested = [[1, 2], [3, [4, 5]], {}]
+console.log(nested.length, "" + nested)
+
+numbers.length = 1
+console.log(numbers.length, "" + numbers)
+
+numbers.length = 3
+console.log(numbers.length, "" + numbers)
+
+console.log(Array.isArray(numbers), Array.isArray({length: 0}), Array.isArray("abc"))
+
+try {
+	numbers.length = 1.5
+} catch (e) {
+	console.log(e.name, numbers.length)
+}
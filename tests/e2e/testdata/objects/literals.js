@@ -0,0 +1,42 @@
+var point = {x: 1, y: 2}
+console.log(point.x, point.y)
+
+var config = {
+	name: "abad",
+	"full name": "a very bad interpreter",
+	10: "ten",
+	0x20: "thirty two",
+	nested: {
+		enabled: true,
+		level: 3,
+	},
+}
+console.log(config.name, config.nested.enabled, config.nested.level)
+
+var keys = ""
+for (var k in config) {
+	keys += k + " "
+}
+console.log(keys)
+
+var celsius = 0
+var temperature = {
+	get fahrenheit() {
+		return celsius * 9 / 5 + 32
+	},
+	set fahrenheit(f) {
+		celsius = (f - 32) * 5 / 9
+	},
+}
+console.log(temperature.fahrenheit)
+temperature.fahrenheit = 212
+console.log(celsius, temperature.fahrenheit)
+
+var last = {a: 1, a: 2}
+console.log(last.a)
+
+console.log("" + {}, {}.toString())
+
+var copy = point
+copy.x = 10
+console.log(point.x)
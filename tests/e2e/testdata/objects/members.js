@@ -0,0 +1,46 @@
+var config = {"full name": "abad", nested: {list: [1, 2, 3]}}
+console.log(config["full name"], config["nested"].list[2])
+
+var key = "name"
+var person = {}
+person[key] = "i4k"
+person["na" + "me"] += "!"
+console.log(person.name)
+
+var matrix = [[1, 2], [3, 4]]
+matrix[1][1] = 40
+console.log(matrix[0][1], matrix[1][1], matrix.length)
+
+var list = []
+list[3] = "d"
+console.log(list.length, "" + list)
+
+function counter() {
+	var n = 0
+	return {
+		inc: function () {
+			n += 1
+			return n
+		},
+		get: function () {
+			return n
+		},
+	}
+}
+console.log(counter().get())
+
+function adder(a) {
+	return function (b) {
+		return a + b
+	}
+}
+console.log(adder(1)(2))
+
+var handlers = {fns: [function (x) { return {double: x * 2} }]}
+console.log(handlers.fns[0](21).double)
+
+try {
+	config.missing[0]
+} catch (e) {
+	console.log(e.name)
+}
@@ -0,0 +1,17 @@
+console.log("abc".length)
+var s = "abc"
+console.log(s[1], s[3], s["length"], "".length)
+var x = 1
+x.y = 2
+console.log(x.y, true.y, s.missing)
+var keys = ""
+for (var i = 0; i < s.length; i++) {
+	keys += s[i] + i
+}
+console.log(keys)
+try {
+	s.foo()
+} catch (e) {
+	console.log(e.name)
+}
+console.log("a".hasOwnProperty("length"), "ab".hasOwnProperty(1), typeof (1).toString)
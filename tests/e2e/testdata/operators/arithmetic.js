@@ -0,0 +1,13 @@
+console.log(1 + 2);
+console.log(1+2*3);
+console.log((1 + 2) * 3);
+console.log(10 - 2 - 3);
+console.log(7 % 3, -7 % 3, 5.5 % 2);
+console.log(1 / 3);
+console.log(1 / 0, -1 / 0, 0 / 0);
+console.log("6" * "7", "0x10" - 1, " 12 " / 4);
+console.log("a" + "b", "a" + 1 + 2, 1 + 2 + "a");
+console.log("" + 1e21, "" + 1e-7, "" + 0.000001);
+console.log(true + true, null + 1, undefined + 1);
+console.log("" + null + undefined + true);
+console.log(-"5", +"");
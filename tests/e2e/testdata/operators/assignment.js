@@ -0,0 +1,19 @@
+a = 1
+a = 2
+console.log(a)
+a += 3
+console.log(a)
+b = 0
+a = b = 7
+console.log(a, b)
+c = "x"
+c += 1
+console.log(c)
+console.foo = 10
+console.foo *= 2
+console.log(console.foo)
+a <<= 2
+a |= 1
+console.log(a)
+a = undefined
+console.log(a)
@@ -0,0 +1,7 @@
+console.log(5 & 3, 5 | 3, 5 ^ 3, ~5, ~-1, ~~3.7, ~~-3.7);
+console.log(1 << 3, 1 << 31, 1 << 32, 1 << 33, 1 << -1);
+console.log(-16 >> 2, -16 >>> 2, -1 >>> 0, -1 >>> 32);
+console.log(4294967296 | 0, 2147483648 | 0, 1e21 | 0, -1e21 | 0);
+console.log(0 / 0 | 0, 1 / 0 | 0, "12" | 0, "0xff" >>> 0);
+console.log(3.9 >> 0, -3.9 >>> 0, 9007199254740993 | 0);
+console.log((31 * 17 + 104) | 0, ((2654435761 * 7) >>> 0) ^ 5);
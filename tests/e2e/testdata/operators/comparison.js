@@ -0,0 +1,11 @@
+console.log(1 < 2, 2 < 1, 1 <= 1, 1 >= 2, 2 > 1);
+console.log(0 / 0 < 1, 0 / 0 >= 1, -0 < 0, -0 <= 0);
+console.log("a" < "b", "a" < "B", "abc" < "abcd", "10" < "9");
+console.log(10 < "9", "10" < 9, "a" < 1, "a" >= 1);
+console.log(undefined < 1, null < 1, null >= 0, null > 0, true > false);
+console.log(null == undefined, null == 0, undefined == 0, null == false);
+console.log("" == 0, "0" == false, "1" == true, "2" == true);
+console.log("  1 " == 1, "0x10" == 16, 0 / 0 == 0 / 0, 0 / 0 != 0 / 0);
+console.log(console == console, console.log == console.log);
+console.log("1" === 1, null === undefined, 0 === -0, 0.1 + 0.2 === 0.3);
+console.log("a" + "b" === "ab", console !== console.log);
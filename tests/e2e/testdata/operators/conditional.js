@@ -0,0 +1,20 @@
+function size(n) {
+	return n > 100 ? "large" : n > 10 ? "medium" : n > 0 ? "small" : "empty"
+}
+
+console.log(size(1000), size(50), size(5), size(0))
+
+var a = 1, b = 2
+var max = a > b ? a : b
+console.log(max)
+
+console.log(true ? false ? 1 : 2 : 3)
+
+var x = (a += 1, b += 1, a * b)
+console.log(x, a, b)
+
+var s = ""
+for (var i = 0, j = 5; i < j; i += 1, j -= 1) {
+	s += i + "" + j + " "
+}
+console.log(s)
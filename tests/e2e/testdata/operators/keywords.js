@@ -0,0 +1,40 @@
+console.log(typeof 1, typeof "a", typeof true, typeof undefined, typeof null)
+console.log(typeof {}, typeof [], typeof function () {}, typeof console.log)
+console.log(typeof undeclared, typeof typeof 1)
+console.log(void 0, void (1 + 2))
+
+var config = {debug: true, level: 2}
+console.log(delete config.debug, "debug" in config, "level" in config)
+console.log(delete config.missing, delete config["level"], "level" in config)
+
+var list = [1, 2, 3]
+console.log(delete list.length, delete list[2], list.length, 2 in list, 0 in list)
+console.log("length" in list, "join" in list, "toString" in config)
+
+var declared = 1
+implicit = 2
+console.log(delete declared, delete implicit, typeof declared, typeof implicit)
+
+function Animal() {}
+function Dog() {}
+Dog.prototype = new Animal()
+var dog = new Dog()
+console.log(dog instanceof Dog, dog instanceof Animal, config instanceof Dog)
+console.log(list instanceof Array, 1 instanceof Animal)
+
+try {
+	"a" in "abc"
+} catch (e) {
+	console.log(e.name)
+}
+
+try {
+	dog instanceof config
+} catch (e) {
+	console.log(e.name)
+}
+
+for (var found = ("level" in config); found; found = false) {
+	console.log("unreachable")
+}
+console.log(found)
@@ -0,0 +1,8 @@
+console.log(true && false, 1 && 2, 0 && "a");
+console.log("" || "a", "a" || "b", null || undefined, 0 / 0 || null);
+console.log(1 && 2 || 3, 0 || 1 && 2);
+console.log(!0, !"", !"0", !!console, !null, !(1 < 2));
+true || console.log("must not be evaluated");
+false && console.log("must not be evaluated");
+false || console.log("evaluated");
+true && console.log("evaluated");
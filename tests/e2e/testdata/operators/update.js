@@ -0,0 +1,33 @@
+var i = 0
+console.log(i++, i, ++i, i, i--, i, --i, i)
+
+var s = "10"
+console.log(s++, s, typeof s)
+
+var counter = {count: 1}
+console.log(counter.count++, --counter.count, counter.count)
+
+var list = [1, 2, 3]
+for (var n = 0; n < list.length; n++) {
+	list[n]++
+}
+console.log("" + list)
+
+var a = 1
+var b = 1
+a
+++b
+console.log(a, b)
+
+function get() { return i }
+try {
+	get()++
+} catch (e) {
+	console.log(e.name)
+}
+
+try {
+	--undeclared
+} catch (e) {
+	console.log(e.name)
+}
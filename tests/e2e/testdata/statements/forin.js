@@ -0,0 +1,27 @@
+function obj() {}
+obj.b = 1
+obj.a = 2
+obj.c = 3
+
+var keys = ""
+for (var k in obj) {
+	keys += k
+}
+console.log(keys)
+
+var n = 0
+for (k in obj) {
+	if (k == "a") continue
+	n += obj.c
+	if (k == "c") break
+}
+console.log(n, k)
+
+for (var i in "xyz") {
+	console.log(i)
+}
+
+for (var x in null) {
+	console.log("never")
+}
+console.log(x)
@@ -0,0 +1,49 @@
+function sign(n) {
+	if (n < 0) {
+		return -1
+	} else if (n > 0) {
+		return 1
+	}
+	return 0
+}
+console.log(sign(-5), sign(0), sign(3))
+
+function fib(n) {
+	if (n < 2) return n
+	return fib(n - 1) + fib(n - 2)
+}
+console.log(fib(20))
+
+function nested(a) {
+	{
+		{
+			if (a) {
+				return "deep"
+			}
+		}
+	}
+	return "shallow"
+}
+console.log(nested(true), nested(false))
+
+if (false) {
+	var hoisted = 1
+}
+console.log(hoisted)
+
+if (true) console.log("no braces")
+else console.log("never")
+
+if (0) console.log("never"); else console.log("else branch")
+
+{
+	var inblock = "block"
+	function blockfn() { return "blockfn" }
+}
+console.log(inblock, blockfn())
+
+var x = 1
+if (x) {
+	x = 2
+}
+console.log(x)
@@ -0,0 +1,26 @@
+var pairs = ""
+outer: for (var i = 0; i < 4; i += 1) {
+	for (var j = 0; j < 4; j += 1) {
+		if (j > i) continue outer
+		if (i == 3) break outer
+		pairs += i + "" + j + " "
+	}
+}
+console.log(pairs)
+
+var n = 0
+scan: {
+	while (true) {
+		n += 1
+		if (n == 3) break scan
+	}
+	n = 100
+}
+console.log(n)
+
+a: b: while (n < 10) {
+	n += 1
+	if (n % 2) continue a
+	if (n > 6) break b
+}
+console.log(n)
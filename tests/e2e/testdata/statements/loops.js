@@ -0,0 +1,61 @@
+var i = 0, sum = 0
+while (i < 10) {
+	i += 1
+	if (i % 2 == 0) continue
+	sum += i
+}
+console.log(sum)
+
+var n = 0
+do {
+	n += 1
+} while (n < 5)
+console.log(n)
+
+do n += 10; while (false)
+console.log(n)
+
+var s = ""
+for (var j = 0; j < 5; j += 1) {
+	s += j
+}
+console.log(s, j)
+
+for (j = 10; ; j -= 1) {
+	if (j < 7) break
+}
+console.log(j)
+
+var found
+for (var k = 0; k < 100; k += 1) {
+	if (k * k > 50) {
+		found = k
+		break
+	}
+}
+console.log(found)
+
+function firstEven(limit) {
+	for (var x = 1; x < limit; x += 1) {
+		if (x % 2 == 0) return x
+	}
+	return -1
+}
+console.log(firstEven(10), firstEven(2))
+
+var outer = 0
+for (var a = 0; a < 3; a += 1) {
+	for (var b = 0; b < 3; b += 1) {
+		if (b == 1) continue
+		if (b == 2) break
+		outer += 1
+	}
+}
+console.log(outer)
+
+var fns = 0
+while (true) {
+	fns += 1
+	if (fns > 3) break
+}
+console.log(fns)
@@ -0,0 +1,44 @@
+function kind(v) {
+	var k = ""
+	switch (v) {
+	case 0:
+		k += "zero "
+	case 1:
+		k += "small"
+		break
+	default:
+		k += "other "
+	case "1":
+		k += "string"
+		break
+	case 10:
+		k = "ten"
+	}
+	return k
+}
+
+console.log(kind(0))
+console.log(kind(1))
+console.log(kind("1"))
+console.log(kind(10))
+console.log(kind(5))
+
+var odd = 0
+for (var i = 0; i < 6; i += 1) {
+	switch (i % 2) {
+	case 0:
+		continue
+	default:
+		odd += 1
+	}
+}
+console.log(odd)
+
+done: switch (true) {
+case true:
+	while (true) {
+		break done
+	}
+	console.log("never")
+}
+console.log("done")
@@ -0,0 +1,60 @@
+function fail(v) {
+	throw v
+}
+
+try {
+	fail("boom")
+	console.log("never")
+} catch (e) {
+	console.log("caught", e)
+}
+
+try {
+	notDefined
+} catch (err) {
+	console.log(err.name, err.message)
+}
+
+try {
+	var x = 1
+	x()
+} catch (err) {
+	console.log("" + err)
+}
+
+var log = ""
+function order() {
+	try {
+		log += "try "
+		return "try"
+	} finally {
+		log += "finally"
+	}
+}
+console.log(order(), log)
+
+function override() {
+	try {
+		throw 1
+	} finally {
+		return "finally wins"
+	}
+}
+console.log(override())
+
+var e = "outer"
+try {
+	throw "inner"
+} catch (e) {
+	e = "changed"
+}
+console.log(e)
+
+for (var i = 0; i < 3; i += 1) {
+	try {
+		if (i == 1) continue
+		console.log("body", i)
+	} finally {
+		console.log("finally", i)
+	}
+}
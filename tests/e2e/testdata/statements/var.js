@@ -0,0 +1,12 @@
+console.log(hoisted)
+var hoisted = "now defined"
+console.log(hoisted)
+
+var a = 1, b = a + 1, c
+console.log(a, b, c)
+
+var a
+console.log(a)
+
+var d = e = 5
+console.log(d, e)
@@ -117,7 +117,7 @@ var names = map[Type]string{
 	LBrace:           "{",
 	RBrace:           "}",
 	LBrack:           "[",
-	RBrack:           "[",
+	RBrack:           "]",
 	Less:             "<",
 	Greater:          ">",
 	LessEq:           "<=",
@@ -201,7 +201,33 @@ func IsNumber(t Type) bool {
 		t == Octal
 }
 
+// IsKeyword tells if t is a reserved word, that can't be used as
+// identifier but can be used as property name.
+func IsKeyword(t Type) bool {
+	return t >= Break && t <= With
+}
+
 func IsUnaryOperator(t Type) bool {
 	return t == Minus ||
-		t == Plus
+		t == Plus ||
+		t == LNot ||
+		t == Not ||
+		t == TypeOf ||
+		t == Void ||
+		t == Delete
+}
+
+func IsAssignOperator(t Type) bool {
+	return t == Assign ||
+		t == AddAssign ||
+		t == SubAssign ||
+		t == MulAssign ||
+		t == RemAssign ||
+		t == QuoAssign ||
+		t == LShiftAssign ||
+		t == RShiftAssign ||
+		t == RShiftZeroAssign ||
+		t == AndAssign ||
+		t == OrAssign ||
+		t == XorAssign
 }
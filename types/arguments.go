@@ -0,0 +1,234 @@
+package types
+
+import (
+	"strconv"
+
+	"github.com/NeowayLabs/abad/internal/utf16"
+)
+
+type (
+	// Bindings is the environment record where the formal parameters
+	// of a function are bound, the mapped indexes of the arguments
+	// object read and write them there.
+	Bindings interface {
+		Get(name utf16.Str, musterr bool) (Value, error)
+		Set(name utf16.Str, value Value, musterr bool) error
+	}
+
+	// Arguments is the arguments object of a function call. In
+	// non-strict code its indexes are mapped to the formal parameters
+	// of the function, changing one of them changes the other.
+	// https://es5.github.io/#x10.6
+	Arguments struct {
+		*DataObject
+
+		// mapped holds the parameter name of the mapped indexes,
+		// the index is unmapped when deleted or redefined.
+		mapped map[string]utf16.Str
+		env    Bindings
+	}
+)
+
+var (
+	calleeAttr = S("callee")
+	callerAttr = S("caller")
+
+	// throwTypeError is the [[ThrowTypeError]] function object.
+	// It's shared by every interpreter, then it can't extend the
+	// Function prototype of any of them.
+	// https://es5.github.io/#x13.2.3
+	throwTypeError = NewBuiltinfn(Null, func(Object, []Value) (Value, error) {
+		return nil, NewTypeError("'caller', 'callee', and 'arguments' " +
+			"properties may not be accessed on strict mode functions or " +
+			"the arguments objects for calls to them")
+	})
+)
+
+// NewArguments creates the arguments object of a call of callee in
+// non-strict code. The indexes of args with a corresponding name in
+// params are mapped to the binding of the parameter in env. If a
+// name is repeated, the last parameter with the name is mapped.
+func NewArguments(
+	proto Value, callee Function, params []utf16.Str, args []Value, env Bindings,
+) *Arguments {
+	a := newArguments(proto, args)
+	a.env = env
+	a.put(calleeAttr, NewDataPropDesc(callee, true, false, true))
+
+	names := map[string]bool{}
+	for i := len(params) - 1; i >= 0; i-- {
+		name := params[i].String()
+		if i >= len(args) || names[name] {
+			continue
+		}
+
+		names[name] = true
+		a.mapped[strconv.Itoa(i)] = params[i]
+	}
+
+	return a
+}
+
+// NewStrictArguments creates the arguments object of a call in strict
+// mode code. It's not mapped to the parameters and accessing its
+// callee or caller properties throws a TypeError.
+func NewStrictArguments(proto Value, args []Value) *Arguments {
+	a := newArguments(proto, args)
+
+	for _, name := range []utf16.Str{callerAttr, calleeAttr} {
+		a.put(name, NewAcessorPropDesc(throwTypeError, throwTypeError,
+			false, false))
+	}
+
+	return a
+}
+
+func newArguments(proto Value, args []Value) *Arguments {
+	obj := NewDataObject(proto)
+	obj.class = "Arguments"
+	obj.put(lengthAttr, NewDataPropDesc(
+		NewNumber(float64(len(args))), true, false, true,
+	))
+
+	for i, arg := range args {
+		obj.put(S(strconv.Itoa(i)), NewDataPropDesc(arg, true, true, true))
+	}
+
+	a := &Arguments{
+		DataObject: obj,
+		mapped:     map[string]utf16.Str{},
+	}
+	obj.Embed(a)
+	return a
+}
+
+func (a *Arguments) ToObject() (Object, error) {
+	return a, nil
+}
+
+// param returns the value of the parameter mapped to name.
+func (a *Arguments) param(name utf16.Str) (Value, bool, error) {
+	param, ok := a.mapped[name.String()]
+	if !ok {
+		return nil, false, nil
+	}
+
+	val, err := a.env.Get(param, false)
+	return val, true, err
+}
+
+// Get is the [[Get]] of arguments objects, the mapped indexes
+// are read from the parameters.
+func (a *Arguments) Get(name utf16.Str) (Value, error) {
+	val, ok, err := a.param(name)
+	if ok {
+		return val, err
+	}
+
+	return a.DataObject.Get(name)
+}
+
+func (a *Arguments) Put(name utf16.Str, val Value, throw bool) error {
+	return putValue(a, name, val, throw)
+}
+
+func (a *Arguments) DefineOwnProperty(
+	name utf16.Str, desc Value, throw bool,
+) (bool, error) {
+	return defineOwnProperty(a, name, desc, throw)
+}
+
+// GetOwnProperty is the [[GetOwnProperty]] of arguments objects.
+// https://es5.github.io/#x10.6
+func (a *Arguments) GetOwnProperty(name utf16.Str) Value {
+	desc, ok := a.getOwnProperty(name)
+	if !ok {
+		return Undefined
+	}
+
+	return desc.ToObject()
+}
+
+func (a *Arguments) getOwnProperty(name utf16.Str) (*PropertyDescriptor, bool) {
+	desc, ok := a.DataObject.getOwnProperty(name)
+	if !ok {
+		return nil, false
+	}
+
+	val, mapped, err := a.param(name)
+	if !mapped || err != nil {
+		return desc, true
+	}
+
+	mappedDesc := NewGenericPropDesc()
+	CopyProperties(mappedDesc, desc)
+	mappedDesc.SetValue(val)
+	return mappedDesc, true
+}
+
+func (a *Arguments) getProperty(name utf16.Str) (*PropertyDescriptor, bool) {
+	desc, ok := a.getOwnProperty(name)
+	if ok {
+		return desc, true
+	}
+
+	return a.DataObject.getProperty(name)
+}
+
+// DefineOwnPropertyP is the [[DefineOwnProperty]] of arguments
+// objects. A new value of a mapped index is also set in the
+// parameter and the index is unmapped when redefined as an
+// accessor or made read only.
+// https://es5.github.io/#x10.6
+func (a *Arguments) DefineOwnPropertyP(
+	name utf16.Str, desc *PropertyDescriptor, throw bool,
+) (bool, error) {
+	val, mapped, err := a.param(name)
+	if err != nil {
+		return false, err
+	}
+
+	readOnly := desc.HasWritable() && desc.Writable().IsFalse()
+	if mapped && readOnly && !desc.HasValue() {
+		// the unmapped index keeps the value of the parameter
+		valueDesc := NewGenericPropDesc()
+		CopyProperties(valueDesc, desc)
+		valueDesc.SetValue(val)
+		desc = valueDesc
+	}
+
+	ok, err := a.DataObject.DefineOwnPropertyP(name, desc, throw)
+	if !ok || !mapped {
+		return ok, err
+	}
+
+	if desc.IsAcessorDescriptor() {
+		delete(a.mapped, name.String())
+		return true, nil
+	}
+
+	if desc.HasValue() {
+		err := a.env.Set(a.mapped[name.String()], desc.Value(), throw)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if readOnly {
+		delete(a.mapped, name.String())
+	}
+
+	return true, nil
+}
+
+// Delete is the [[Delete]] of arguments objects, a deleted
+// index is no longer mapped.
+// https://es5.github.io/#x10.6
+func (a *Arguments) Delete(name utf16.Str, throw bool) (bool, error) {
+	ok, err := a.DataObject.Delete(name, throw)
+	if ok {
+		delete(a.mapped, name.String())
+	}
+
+	return ok, err
+}
@@ -0,0 +1,164 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/abad/envrec"
+	"github.com/NeowayLabs/abad/internal/utf16"
+	"github.com/NeowayLabs/abad/types"
+	"github.com/madlambda/spells/assert"
+)
+
+// newParams binds the parameters names to args in a new
+// environment, like a function call does.
+func newParams(t *testing.T, names []string, args []types.Value) (
+	[]utf16.Str, *envrec.Decl,
+) {
+	t.Helper()
+
+	env := envrec.NewDeclEnv()
+	params := make([]utf16.Str, len(names))
+	for i, name := range names {
+		params[i] = S(name)
+
+		var val types.Value = types.Undefined
+		if i < len(args) {
+			val = args[i]
+		}
+
+		assert.NoError(t, env.New(params[i], false), "new %s", name)
+		assert.NoError(t, env.Set(params[i], val, true), "set %s", name)
+	}
+
+	return params, env
+}
+
+func assertGet(t *testing.T, obj types.Object, name string, want types.Value) {
+	t.Helper()
+
+	got, err := obj.Get(S(name))
+	assert.NoError(t, err, "get %s", name)
+	if !types.StrictEqual(got, want) {
+		t.Fatalf("expected %s to be %s but got %s", name, want, got)
+	}
+}
+
+func assertBinding(t *testing.T, env envrec.Env, name string, want types.Value) {
+	t.Helper()
+
+	got, err := env.Get(S(name), true)
+	assert.NoError(t, err, "get binding %s", name)
+	if !types.StrictEqual(got, want) {
+		t.Fatalf("expected binding %s to be %s but got %s", name, want, got)
+	}
+}
+
+func TestArguments(t *testing.T) {
+	one, two, three := types.NewNumber(1), types.NewNumber(2), types.NewNumber(3)
+	args := []types.Value{one, two, three}
+	params, env := newParams(t, []string{"a", "b"}, args)
+	callee := types.NewBuiltinfn(types.Null, nil)
+
+	argsobj := types.NewArguments(types.Null, callee, params, args, env)
+	assert.EqualStrings(t, "Arguments", argsobj.Class(), "arguments class")
+	assertGet(t, argsobj, "length", three)
+	assertGet(t, argsobj, "0", one)
+	assertGet(t, argsobj, "2", three)
+	assertGet(t, argsobj, "callee", callee)
+
+	if len(argsobj.Enumerate()) != 3 {
+		t.Fatalf("only indexes must be enumerable: %v", argsobj.Enumerate())
+	}
+}
+
+func TestArgumentsMapped(t *testing.T) {
+	one, two := types.NewNumber(1), types.NewNumber(2)
+	args := []types.Value{one}
+	params, env := newParams(t, []string{"a", "b"}, args)
+	argsobj := types.NewArguments(types.Null, types.NewBuiltinfn(types.Null, nil),
+		params, args, env)
+
+	assert.NoError(t, env.Set(S("a"), two, true), "set a")
+	assertGet(t, argsobj, "0", two)
+
+	assert.NoError(t, argsobj.Put(S("0"), one, true), "put 0")
+	assertBinding(t, env, "a", one)
+
+	// b has no argument, then it's not mapped
+	assert.NoError(t, argsobj.Put(S("1"), two, true), "put 1")
+	assertBinding(t, env, "b", types.Undefined)
+
+	deleted, err := argsobj.Delete(S("0"), true)
+	assert.NoError(t, err, "delete 0")
+	if !deleted {
+		t.Fatal("index 0 must be deleted")
+	}
+
+	assert.NoError(t, argsobj.Put(S("0"), two, true), "put 0 after delete")
+	assertBinding(t, env, "a", one)
+}
+
+func TestArgumentsUnmappedByDefine(t *testing.T) {
+	one, two := types.NewNumber(1), types.NewNumber(2)
+	args := []types.Value{one, one}
+	params, env := newParams(t, []string{"a", "b"}, args)
+	argsobj := types.NewArguments(types.Null, types.NewBuiltinfn(types.Null, nil),
+		params, args, env)
+
+	assert.NoError(t, env.Set(S("a"), two, true), "set a")
+
+	readOnly := types.NewGenericPropDesc()
+	readOnly.SetWritable(types.False)
+	_, err := argsobj.DefineOwnPropertyP(S("0"), readOnly, true)
+	assert.NoError(t, err, "make 0 read only")
+
+	// the index keeps the last value of the parameter
+	assert.NoError(t, env.Set(S("a"), one, true), "set a")
+	assertGet(t, argsobj, "0", two)
+
+	getter := types.NewBuiltinfn(types.Null,
+		func(types.Object, []types.Value) (types.Value, error) {
+			return types.NewNumber(3), nil
+		},
+	)
+	accessor := types.NewAcessorPropDesc(getter, types.Undefined, true, true)
+	_, err = argsobj.DefineOwnPropertyP(S("1"), accessor, true)
+	assert.NoError(t, err, "define 1 as accessor")
+
+	assert.NoError(t, env.Set(S("b"), two, true), "set b")
+	assertGet(t, argsobj, "1", types.NewNumber(3))
+}
+
+func TestArgumentsDuplicatedParams(t *testing.T) {
+	one, two := types.NewNumber(1), types.NewNumber(2)
+	args := []types.Value{one, two}
+	params, env := newParams(t, []string{"a", "a"}, args)
+	argsobj := types.NewArguments(types.Null, types.NewBuiltinfn(types.Null, nil),
+		params, args, env)
+
+	assertBinding(t, env, "a", two)
+	assertGet(t, argsobj, "0", one)
+	assertGet(t, argsobj, "1", two)
+
+	assert.NoError(t, argsobj.Put(S("0"), types.NewNumber(3), true), "put 0")
+	assertBinding(t, env, "a", two)
+}
+
+func TestStrictArguments(t *testing.T) {
+	one := types.NewNumber(1)
+	argsobj := types.NewStrictArguments(types.Null, []types.Value{one})
+	assertGet(t, argsobj, "length", one)
+	assertGet(t, argsobj, "0", one)
+
+	wantErr := types.NewTypeError("'caller', 'callee', and 'arguments' " +
+		"properties may not be accessed on strict mode functions or " +
+		"the arguments objects for calls to them")
+
+	for _, name := range []string{"callee", "caller"} {
+		_, err := argsobj.Get(S(name))
+		assert.EqualErrs(t, wantErr, err, "get %s", name)
+
+		err = argsobj.Put(S(name), one, true)
+		assert.EqualErrs(t, wantErr, err, "put %s", name)
+	}
+}
@@ -0,0 +1,193 @@
+package types
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/NeowayLabs/abad/internal/utf16"
+)
+
+type (
+	// Array is the array object, its length property is kept in
+	// sync with the array index properties.
+	// https://es5.github.io/#x15.4
+	Array struct {
+		*DataObject
+	}
+)
+
+// NewArray creates an empty array using proto as prototype.
+// https://es5.github.io/#x15.4.5
+func NewArray(proto Value) *Array {
+	obj := NewDataObject(proto)
+	obj.class = "Array"
+	obj.put(lengthAttr, NewDataPropDesc(NewNumber(0), true, false, false))
+
+	arr := &Array{
+		DataObject: obj,
+	}
+	obj.Embed(arr)
+	return arr
+}
+
+// Len returns the value of the length property.
+func (a *Array) Len() uint32 {
+	desc, _ := a.getOwnProperty(lengthAttr)
+	return desc.Value().ToNumber().ToUint32()
+}
+
+func (a *Array) ToObject() (Object, error) {
+	return a, nil
+}
+
+// Put is the [[Put]] of arrays, it uses the array
+// [[DefineOwnProperty]] to keep the length updated.
+func (a *Array) Put(name utf16.Str, val Value, throw bool) error {
+	return putValue(a, name, val, throw)
+}
+
+func (a *Array) DefineOwnProperty(
+	name utf16.Str, desc Value, throw bool,
+) (bool, error) {
+	return defineOwnProperty(a, name, desc, throw)
+}
+
+// DefineOwnPropertyP is the [[DefineOwnProperty]] of arrays.
+// Setting length deletes the indexes past the new length and
+// adding an index at or past length grows it.
+// https://es5.github.io/#x15.4.5.1
+func (a *Array) DefineOwnPropertyP(
+	name utf16.Str, desc *PropertyDescriptor, throw bool,
+) (bool, error) {
+	if name.String() == lengthAttr.String() {
+		return a.defineLength(desc, throw)
+	}
+
+	index, ok := arrayIndex(name.String())
+	if !ok {
+		return a.DataObject.DefineOwnPropertyP(name, desc, throw)
+	}
+
+	oldLenDesc, _ := a.getOwnProperty(lengthAttr)
+	oldLen := a.Len()
+	if index >= oldLen && oldLenDesc.Writable().IsFalse() {
+		if throw {
+			return false, NewTypeError(
+				"Cannot add property %s, length is read only", name,
+			)
+		}
+
+		return false, nil
+	}
+
+	ok, err := a.DataObject.DefineOwnPropertyP(name, desc, throw)
+	if !ok {
+		return false, err
+	}
+
+	if index >= oldLen {
+		lenDesc := NewGenericPropDesc()
+		lenDesc.SetValue(NewNumber(float64(index) + 1))
+		a.DataObject.DefineOwnPropertyP(lengthAttr, lenDesc, false)
+	}
+
+	return true, nil
+}
+
+// defineLength is the step 3 of the array [[DefineOwnProperty]].
+func (a *Array) defineLength(desc *PropertyDescriptor, throw bool) (bool, error) {
+	if !desc.HasValue() {
+		return a.DataObject.DefineOwnPropertyP(lengthAttr, desc, throw)
+	}
+
+	num, err := ToNumber(desc.Value())
+	if err != nil {
+		return false, err
+	}
+
+	newLen := num.ToUint32()
+	if float64(newLen) != num.Value() {
+		return false, NewRangeError("Invalid array length")
+	}
+
+	newLenDesc := NewGenericPropDesc()
+	CopyProperties(newLenDesc, desc)
+	newLenDesc.SetValue(NewNumber(float64(newLen)))
+
+	oldLenDesc, _ := a.getOwnProperty(lengthAttr)
+	if newLen >= a.Len() {
+		return a.DataObject.DefineOwnPropertyP(lengthAttr, newLenDesc, throw)
+	}
+
+	if oldLenDesc.Writable().IsFalse() {
+		if throw {
+			return false, NewTypeError(
+				"Cannot assign to read only property 'length' of [object Array]",
+			)
+		}
+
+		return false, nil
+	}
+
+	// length is made read only after the elements are deleted
+	newWritable := !newLenDesc.HasWritable() ||
+		newLenDesc.Writable().IsTrue()
+	if !newWritable {
+		newLenDesc.SetWritable(True)
+	}
+
+	ok, err := a.DataObject.DefineOwnPropertyP(lengthAttr, newLenDesc, throw)
+	if !ok {
+		return false, err
+	}
+
+	// Only the existent indexes are deleted, in descending order,
+	// instead of every index in the range [newLen, oldLen).
+	for _, index := range a.indexesFrom(newLen) {
+		deleted, _ := a.Delete(S(strconv.FormatUint(uint64(index), 10)), false)
+		if deleted {
+			continue
+		}
+
+		newLenDesc.SetValue(NewNumber(float64(index) + 1))
+		if !newWritable {
+			newLenDesc.SetWritable(False)
+		}
+
+		a.DataObject.DefineOwnPropertyP(lengthAttr, newLenDesc, false)
+
+		if throw {
+			return false, NewTypeError(
+				"Cannot delete property '%d' of [object Array]", index,
+			)
+		}
+
+		return false, nil
+	}
+
+	if !newWritable {
+		wrDesc := NewGenericPropDesc()
+		wrDesc.SetWritable(False)
+		a.DataObject.DefineOwnPropertyP(lengthAttr, wrDesc, false)
+	}
+
+	return true, nil
+}
+
+// indexesFrom returns the array indexes greater than or equal to
+// start in descending order.
+func (a *Array) indexesFrom(start uint32) []uint32 {
+	var indexes []uint32
+	for _, key := range a.keys {
+		index, ok := arrayIndex(key)
+		if ok && index >= start {
+			indexes = append(indexes, index)
+		}
+	}
+
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i] > indexes[j]
+	})
+
+	return indexes
+}
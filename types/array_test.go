@@ -0,0 +1,163 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/abad/types"
+	"github.com/madlambda/spells/assert"
+)
+
+var lengthAttr = S("length")
+
+func assertArrayLen(t *testing.T, arr *types.Array, want uint32) {
+	t.Helper()
+
+	if arr.Len() != want {
+		t.Fatalf("expected length %d but got %d", want, arr.Len())
+	}
+
+	length, err := arr.Get(lengthAttr)
+	assert.NoError(t, err, "get length")
+	if !types.StrictEqual(length, types.NewNumber(float64(want))) {
+		t.Fatalf("expected length property %d but got %s", want, length)
+	}
+}
+
+func TestArrayIsEmpty(t *testing.T) {
+	arr := types.NewArray(types.Null)
+	assert.EqualStrings(t, "Array", arr.Class(), "array class")
+	assertArrayLen(t, arr, 0)
+
+	if len(arr.Enumerate()) != 0 {
+		t.Fatalf("length must not be enumerable: %v", arr.Enumerate())
+	}
+
+	deleted, err := arr.Delete(lengthAttr, false)
+	assert.NoError(t, err, "delete length")
+	if deleted {
+		t.Fatal("length must not be configurable")
+	}
+}
+
+func TestArrayIndexGrowsLength(t *testing.T) {
+	arr := types.NewArray(types.Null)
+
+	for _, tc := range []struct {
+		name string
+		want uint32
+	}{
+		{name: "0", want: 1},
+		{name: "1", want: 2},
+		{name: "10", want: 11},
+		{name: "5", want: 11},
+		{name: "01", want: 11},
+		{name: "a", want: 11},
+		{name: "4294967295", want: 11},
+		{name: "4294967294", want: 4294967295},
+	} {
+		err := arr.Put(S(tc.name), types.NewNumber(1), true)
+		assert.NoError(t, err, "put %s", tc.name)
+		assertArrayLen(t, arr, tc.want)
+	}
+}
+
+func TestArrayLengthTruncates(t *testing.T) {
+	arr := types.NewArray(types.Null)
+	for _, name := range []string{"0", "1", "2", "a"} {
+		err := arr.Put(S(name), types.NewNumber(1), true)
+		assert.NoError(t, err, "put %s", name)
+	}
+
+	err := arr.Put(lengthAttr, types.NewNumber(1), true)
+	assert.NoError(t, err, "truncate")
+	assertArrayLen(t, arr, 1)
+
+	for _, name := range []string{"1", "2"} {
+		if arr.HasProperty(S(name)) {
+			t.Fatalf("index %s must be deleted", name)
+		}
+	}
+
+	for _, name := range []string{"0", "a"} {
+		if !arr.HasProperty(S(name)) {
+			t.Fatalf("property %s must not be deleted", name)
+		}
+	}
+
+	err = arr.Put(lengthAttr, types.NewString("5"), true)
+	assert.NoError(t, err, "grow")
+	assertArrayLen(t, arr, 5)
+}
+
+func TestArrayLengthStopsAtNonConfigurable(t *testing.T) {
+	arr := types.NewArray(types.Null)
+	for _, name := range []string{"0", "1", "2"} {
+		err := arr.Put(S(name), types.NewNumber(1), true)
+		assert.NoError(t, err, "put %s", name)
+	}
+
+	_, err := arr.DefineOwnPropertyP(S("1"),
+		types.NewDataPropDesc(types.NewNumber(1), true, true, false), true)
+	assert.NoError(t, err, "define non configurable index")
+
+	err = arr.Put(lengthAttr, types.NewNumber(0), true)
+	assert.EqualErrs(t, types.NewTypeError(
+		"Cannot delete property '1' of [object Array]",
+	), err, "truncate")
+	assertArrayLen(t, arr, 2)
+}
+
+func TestArrayInvalidLength(t *testing.T) {
+	arr := types.NewArray(types.Null)
+
+	for _, val := range []types.Value{
+		types.NewNumber(-1),
+		types.NewNumber(1.5),
+		types.NewNumber(4294967296),
+		types.NewString("a"),
+	} {
+		err := arr.Put(lengthAttr, val, false)
+		assert.EqualErrs(t, types.NewRangeError("Invalid array length"),
+			err, "length %s", val)
+		assertArrayLen(t, arr, 0)
+	}
+}
+
+func TestArrayLengthConversionError(t *testing.T) {
+	arr := types.NewArray(types.Null)
+	thrown := types.NewThrownValue(types.NewString("v"))
+
+	length := types.NewBaseDataObject()
+	valueOf := types.NewBuiltinfn(types.Null,
+		func(types.Object, []types.Value) (types.Value, error) {
+			return nil, thrown
+		},
+	)
+	assert.NoError(t, length.Put(S("valueOf"), valueOf, true), "put valueOf")
+
+	err := arr.Put(lengthAttr, length, false)
+	assert.EqualErrs(t, thrown, err, "length conversion")
+	assertArrayLen(t, arr, 0)
+}
+
+func TestArrayReadOnlyLength(t *testing.T) {
+	arr := types.NewArray(types.Null)
+	err := arr.Put(S("0"), types.NewNumber(1), true)
+	assert.NoError(t, err, "put 0")
+
+	desc := types.NewGenericPropDesc()
+	desc.SetWritable(types.False)
+	_, err = arr.DefineOwnPropertyP(lengthAttr, desc, true)
+	assert.NoError(t, err, "make length read only")
+
+	ok, err := arr.DefineOwnPropertyP(S("1"),
+		types.NewDataPropDesc(types.NewNumber(1), true, true, true), false)
+	assert.NoError(t, err, "add index")
+	if ok {
+		t.Fatal("index past a read only length must be rejected")
+	}
+
+	err = arr.Put(lengthAttr, types.NewNumber(0), false)
+	assert.NoError(t, err, "truncate")
+	assertArrayLen(t, arr, 1)
+}
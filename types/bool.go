@@ -34,9 +34,10 @@ func (b Bool) ToPrimitive(hint Kind) (Value, error) {
 	return b, nil
 }
 
+// ToObject wraps the boolean in an object with no prototype, the
+// interpreter uses the ToObject function instead.
 func (b Bool) ToObject() (Object, error) {
-	panic("not implemented")
-	return nil, nil
+	return newPrimitiveObject(Null, "Boolean"), nil
 }
 
 func (b Bool) Equal(a Bool) bool {
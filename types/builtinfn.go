@@ -1,28 +1,65 @@
 package types
 
 type (
-	Execfn    func(this Object, args []Value) Value
+	Execfn    func(this Object, args []Value) (Value, error)
 	Builtinfn struct {
-		*UserFunction
+		*DataObject
 
 		fn Execfn
 	}
+
+	// Constructfn creates the object of a new expression.
+	Constructfn func(args []Value) (Value, error)
+
+	// BuiltinConstructor is a built-in function that can be
+	// used with the new operator, eg.: Array.
+	// The other built-in functions do not have [[Construct]].
+	// https://es5.github.io/#x15
+	BuiltinConstructor struct {
+		*Builtinfn
+
+		construct Constructfn
+	}
 )
 
-func NewBuiltinfn(fn Execfn) *Builtinfn {
-	return &Builtinfn{
+// NewBuiltinfn creates a built-in function that executes fn when
+// called, proto is the Function prototype.
+func NewBuiltinfn(proto Value, fn Execfn) *Builtinfn {
+	f := &Builtinfn{
 		fn: fn,
 
-		UserFunction: &UserFunction{
-			DataObject: NewDataObject(NewUserFunctionPrototype()),
-		},
+		DataObject: newFunctionObject(proto),
 	}
+	f.Embed(f)
+	return f
 }
 
-func (f *Builtinfn) Call(this Object, args []Value) Value {
+func (f *Builtinfn) Call(this Object, args []Value) (Value, error) {
 	return f.fn(this, args)
 }
 
 func (f *Builtinfn) ToObject() (Object, error) {
 	return f, nil
 }
+
+// NewBuiltinConstructor creates a built-in function that executes
+// fn when called and construct when used with the new operator.
+func NewBuiltinConstructor(
+	proto Value, fn Execfn, construct Constructfn,
+) *BuiltinConstructor {
+	f := &BuiltinConstructor{
+		Builtinfn: NewBuiltinfn(proto, fn),
+		construct: construct,
+	}
+	f.Embed(f)
+	return f
+}
+
+// Construct is the [[Construct]] internal method.
+func (f *BuiltinConstructor) Construct(args []Value) (Value, error) {
+	return f.construct(args)
+}
+
+func (f *BuiltinConstructor) ToObject() (Object, error) {
+	return f, nil
+}
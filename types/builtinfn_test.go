@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/NeowayLabs/abad/types"
+	"github.com/madlambda/spells/assert"
 )
 
 var Str = types.NewString
@@ -16,31 +17,75 @@ func TestBuiltin(t *testing.T) {
 	}{
 		{
 			input: []types.Value{Str("hello"), Str("world")},
-			fn: func(obj types.Object, args []types.Value) types.Value {
-				return types.Undefined
+			fn: func(obj types.Object, args []types.Value) (types.Value, error) {
+				return types.Undefined, nil
 			},
 			output: types.Undefined,
 		},
 		{
 			input: []types.Value{Str("hello"), Str("world")},
-			fn: func(obj types.Object, args []types.Value) types.Value {
-				return args[0]
+			fn: func(obj types.Object, args []types.Value) (types.Value, error) {
+				return args[0], nil
 			},
 			output: Str("hello"),
 		},
 		{
 			input: []types.Value{Str("hello"), Str("world")},
-			fn: func(obj types.Object, args []types.Value) types.Value {
-				return types.NewNumber(float64(len(args)))
+			fn: func(obj types.Object, args []types.Value) (types.Value, error) {
+				return types.NewNumber(float64(len(args))), nil
 			},
 			output: types.NewNumber(2.0),
 		},
 	} {
 		global := types.NewBaseDataObject()
-		builtin := types.NewBuiltinfn(tc.fn)
-		got := builtin.Call(global, tc.input)
+		builtin := types.NewBuiltinfn(types.Null, tc.fn)
+		got, err := builtin.Call(global, tc.input)
+		assert.NoError(t, err, "builtin call failed")
+
 		if !types.StrictEqual(tc.output, got) {
 			t.Fatalf("values differ: '%s' != '%s'", got, tc.output)
 		}
 	}
 }
+
+func TestBuiltinError(t *testing.T) {
+	want := types.NewTypeError("failed")
+	builtin := types.NewBuiltinfn(types.Null, func(types.Object, []types.Value) (types.Value, error) {
+		return nil, want
+	})
+
+	_, err := builtin.Call(types.NewBaseDataObject(), nil)
+	assert.EqualErrs(t, want, err, "builtin error")
+}
+
+func TestBuiltinConstructor(t *testing.T) {
+	var fn types.Value = types.NewBuiltinfn(types.Null, func(types.Object, []types.Value) (types.Value, error) {
+		return types.Undefined, nil
+	})
+
+	if _, ok := fn.(types.Constructor); ok {
+		t.Fatal("built-in functions must not implement [[Construct]]")
+	}
+
+	obj := types.NewBaseDataObject()
+	var ctor types.Value = types.NewBuiltinConstructor(types.Null,
+		func(types.Object, []types.Value) (types.Value, error) {
+			return types.Undefined, nil
+		},
+		func([]types.Value) (types.Value, error) {
+			return obj, nil
+		},
+	)
+
+	constructor, ok := ctor.(types.Constructor)
+	if !ok {
+		t.Fatal("built-in constructors must implement [[Construct]]")
+	}
+
+	got, err := constructor.Construct(nil)
+	assert.NoError(t, err, "construct")
+
+	if !types.StrictEqual(obj, got) {
+		t.Fatalf("unexpected constructed value: %s", got)
+	}
+}
@@ -2,5 +2,10 @@
 // primitypes types, the Object type and the Object
 // wrappers for each primitive type.
 //
+// The environment records are implemented by the envrec package,
+// that depends on this one and then can't be imported here. The
+// interfaces of this package that they implement are checked
+// there at compile time.
+//
 // Documentation: https://es5.github.io/#x8
 package types
@@ -2,14 +2,56 @@ package types
 
 import (
 	"fmt"
+
+	"github.com/NeowayLabs/abad/internal/utf16"
 )
 
 type (
+	// Exception is an error that can be caught by ecmascript
+	// code, Value is the value bound to the catch parameter.
+	// https://es5.github.io/#x12.14
+	Exception interface {
+		error
+		Value() Value
+	}
+
+	// ThrownValue is the exception raised by the throw statement,
+	// any value can be thrown.
+	// https://es5.github.io/#x12.13
+	ThrownValue struct {
+		value Value
+	}
+
 	TypeError struct {
 		msg string
 	}
+
+	ReferenceError struct {
+		msg string
+	}
+
+	RangeError struct {
+		msg string
+	}
 )
 
+var (
+	nameAttr    = S("name")
+	messageAttr = S("message")
+)
+
+func NewThrownValue(value Value) ThrownValue {
+	return ThrownValue{
+		value: value,
+	}
+}
+
+func (e ThrownValue) Error() string {
+	return fmt.Sprintf("Uncaught %s", e.value.ToString())
+}
+
+func (e ThrownValue) Value() Value { return e.value }
+
 func NewTypeError(format string, args ...interface{}) TypeError {
 	err := TypeError{
 		msg: fmt.Sprintf(format, args...),
@@ -23,4 +65,95 @@ func (e TypeError) Error() string {
 	return fmt.Sprintf("TypeError: %s\n\tat anonymous:1:1", e.msg)
 }
 
-func (e TypeError) Exception() bool { return true }
+// Value returns a new TypeError object.
+func (e TypeError) Value() Value { return newErrorObject("TypeError", e.msg) }
+
+func NewReferenceError(format string, args ...interface{}) ReferenceError {
+	err := ReferenceError{
+		msg: fmt.Sprintf(format, args...),
+	}
+
+	return err
+}
+
+func (e ReferenceError) Error() string {
+	return fmt.Sprintf("ReferenceError: %s\n\tat anonymous:1:1", e.msg)
+}
+
+// Value returns a new ReferenceError object.
+func (e ReferenceError) Value() Value {
+	return newErrorObject("ReferenceError", e.msg)
+}
+
+func NewRangeError(format string, args ...interface{}) RangeError {
+	err := RangeError{
+		msg: fmt.Sprintf(format, args...),
+	}
+
+	return err
+}
+
+func (e RangeError) Error() string {
+	return fmt.Sprintf("RangeError: %s\n\tat anonymous:1:1", e.msg)
+}
+
+// Value returns a new RangeError object.
+func (e RangeError) Value() Value {
+	return newErrorObject("RangeError", e.msg)
+}
+
+// newErrorObject creates the object of the native errors.
+// TODO(i4k): The Error constructors and prototypes are not
+// implemented yet, then name and toString are own properties
+// and toString doesn't extend the Function prototype.
+// https://es5.github.io/#x15.11
+func newErrorObject(name, msg string) *DataObject {
+	obj := NewBaseDataObject()
+	obj.class = "Error"
+
+	for _, prop := range []struct {
+		name  utf16.Str
+		value Value
+	}{
+		{name: nameAttr, value: NewString(name)},
+		{name: messageAttr, value: NewString(msg)},
+		{name: toStringAttr, value: NewBuiltinfn(Null, errorToString)},
+	} {
+		obj.DefineOwnPropertyP(prop.name,
+			NewDataPropDesc(prop.value, true, false, true), false)
+	}
+
+	return obj
+}
+
+// https://es5.github.io/#x15.11.4.4
+func errorToString(this Object, _ []Value) (Value, error) {
+	name, err := this.Get(nameAttr)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := this.Get(messageAttr)
+	if err != nil {
+		return nil, err
+	}
+
+	namestr := "Error"
+	if !StrictEqual(name, Undefined) {
+		namestr = name.ToString().String()
+	}
+
+	msgstr := ""
+	if !StrictEqual(msg, Undefined) {
+		msgstr = msg.ToString().String()
+	}
+
+	switch {
+	case msgstr == "":
+		return NewString(namestr), nil
+	case namestr == "":
+		return NewString(msgstr), nil
+	}
+
+	return NewString(namestr + ": " + msgstr), nil
+}
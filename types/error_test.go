@@ -0,0 +1,32 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/abad/types"
+)
+
+func TestExceptionValue(t *testing.T) {
+	for _, tc := range []struct {
+		err  types.Exception
+		want string
+	}{
+		{err: types.NewTypeError("a is not a function"), want: "TypeError: a is not a function"},
+		{err: types.NewReferenceError("a is not defined"), want: "ReferenceError: a is not defined"},
+		{err: types.NewRangeError("Invalid array length"), want: "RangeError: Invalid array length"},
+		{err: types.NewThrownValue(types.NewNumber(1)), want: "1"},
+		{err: types.NewThrownValue(types.NewString("error")), want: "error"},
+	} {
+		got := tc.err.Value().ToString().String()
+		if got != tc.want {
+			t.Fatalf("expected %q but got %q", tc.want, got)
+		}
+	}
+}
+
+func TestThrownValueError(t *testing.T) {
+	err := types.NewThrownValue(types.NewTypeError("failed").Value())
+	if err.Error() != "Uncaught TypeError: failed" {
+		t.Fatalf("unexpected error message: %s", err)
+	}
+}
@@ -3,14 +3,13 @@ package types
 import (
 	"math"
 	"strconv"
+	"strings"
 )
 
 type (
 	Number float64
 )
 
-var ε = math.Nextafter(1, 2) - 1
-
 func NewNumber(a float64) Number {
 	return Number(a)
 }
@@ -18,7 +17,7 @@ func NewNumber(a float64) Number {
 func (a Number) Value() float64 { return float64(a) }
 
 func (a Number) String() string {
-	return strconv.FormatFloat(float64(a), 'f', -1, 64)
+	return numberToString(float64(a))
 }
 
 // https://es5.github.io/#x9.2
@@ -49,34 +48,116 @@ func (a Number) ToNumber() Number {
 }
 
 // ToString converts the number to string.
-// Check https://es5.github.io/#x9.8
-// TODO(i4k): revisit this.
+// https://es5.github.io/#x9.8.1
 func (a Number) ToString() String {
-	val := strconv.FormatFloat(float64(a), 'f', -1, 64)
-	return NewString(val)
+	return NewString(numberToString(float64(a)))
+}
+
+// ToInt32 converts the number to a signed 32 bits integer.
+// https://es5.github.io/#x9.5
+func (a Number) ToInt32() int32 {
+	// WHY: both conversions only differ in how the 32 bits
+	// are interpreted, what Go conversion does for free.
+	return int32(a.ToUint32())
+}
+
+// ToUint32 converts the number to an unsigned 32 bits integer,
+// ie. the integer part of a modulo 2^32.
+// https://es5.github.io/#x9.6
+func (a Number) ToUint32() uint32 {
+	f := float64(a)
+	if math.IsNaN(f) || math.IsInf(f, 0) || f == 0 {
+		return 0
+	}
+
+	const two32 = 1 << 32
+
+	// math.Mod is exact, then there's no precision loss
+	// even for big values.
+	int32bit := math.Mod(math.Trunc(f), two32)
+	if int32bit < 0 {
+		int32bit += two32
+	}
+
+	return uint32(int32bit)
 }
 
 func (_ Number) Kind() Kind {
 	return KindNumber
 }
 
+// Equal compares numbers using the === rules, ie. NaN is
+// not equal to anything and +0 is equal to -0.
+// https://es5.github.io/#x11.9.6
 func (a Number) Equal(b Number) bool {
-	if math.IsNaN(a.Value()) ||
-		math.IsNaN(b.Value()) {
-		return false
-	}
-
-	return equalValues(a.Value(), b.Value())
+	return a.Value() == b.Value()
 }
 
 func (a Number) ToPrimitive(hint Kind) (Value, error) {
 	return a, nil
 }
 
+// ToObject wraps the number in an object with no prototype, the
+// interpreter uses the ToObject function instead.
 func (a Number) ToObject() (Object, error) {
-	panic("not implemented")
+	return newPrimitiveObject(Null, "Number"), nil
 }
 
-func equalValues(a, b float64) bool {
-	return math.Abs(a-b) < ε && math.Abs(b-a) < ε
+// numberToString implements the ToString algorithm applied to
+// the Number type.
+// https://es5.github.io/#x9.8.1
+func numberToString(m float64) string {
+	switch {
+	case math.IsNaN(m):
+		return "NaN"
+	case m == 0:
+		// +0 and -0
+		return "0"
+	case m < 0:
+		return "-" + numberToString(-m)
+	case math.IsInf(m, 1):
+		return "Infinity"
+	}
+
+	// the shortest decimal digits that represent m, in the
+	// format d.ddde±x
+	exp := strconv.FormatFloat(m, 'e', -1, 64)
+	epos := strings.IndexByte(exp, 'e')
+
+	digits := strings.Replace(exp[:epos], ".", "", 1)
+	e, _ := strconv.Atoi(exp[epos+1:])
+
+	// the spec names: m = s * 10^(n-k), where k is the
+	// number of digits in s.
+	k := len(digits)
+	n := e + 1
+
+	switch {
+	case k <= n && n <= 21:
+		return digits + strings.Repeat("0", n-k)
+	case 0 < n && n <= 21:
+		return digits[:n] + "." + digits[n:]
+	case -6 < n && n <= 0:
+		return "0." + strings.Repeat("0", -n) + digits
+	}
+
+	sign := "+"
+	if n-1 < 0 {
+		sign = "-"
+	}
+
+	exponent := sign + strconv.Itoa(abs(n-1))
+
+	if k == 1 {
+		return digits + "e" + exponent
+	}
+
+	return digits[:1] + "." + digits[1:] + "e" + exponent
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
 }
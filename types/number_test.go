@@ -0,0 +1,81 @@
+package types_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NeowayLabs/abad/types"
+	"github.com/madlambda/spells/assert"
+)
+
+func TestNumberToString(t *testing.T) {
+	for _, tc := range []struct {
+		num  float64
+		want string
+	}{
+		{num: 0, want: "0"},
+		{num: math.Copysign(0, -1), want: "0"},
+		{num: 1, want: "1"},
+		{num: -1, want: "-1"},
+		{num: 666, want: "666"},
+		{num: 0.5, want: "0.5"},
+		{num: -1.25, want: "-1.25"},
+		{num: 1e10, want: "10000000000"},
+		{num: 1e20, want: "100000000000000000000"},
+		{num: 1e21, want: "1e+21"},
+		{num: 1.5e21, want: "1.5e+21"},
+		{num: 123456789.123, want: "123456789.123"},
+		{num: 0.000001, want: "0.000001"},
+		{num: 0.0000001, want: "1e-7"},
+		{num: 1.5e-10, want: "1.5e-10"},
+		{num: 0.30000000000000004, want: "0.30000000000000004"},
+		{num: math.MaxFloat64, want: "1.7976931348623157e+308"},
+		{num: 5e-324, want: "5e-324"},
+		{num: math.NaN(), want: "NaN"},
+		{num: math.Inf(1), want: "Infinity"},
+		{num: math.Inf(-1), want: "-Infinity"},
+	} {
+		got := types.NewNumber(tc.num).ToString()
+		assert.EqualStrings(t, tc.want, got.String(),
+			"number to string")
+	}
+}
+
+func TestNumberToInt32(t *testing.T) {
+	for _, tc := range []struct {
+		num    float64
+		int32  int32
+		uint32 uint32
+	}{
+		{num: 0, int32: 0, uint32: 0},
+		{num: math.Copysign(0, -1), int32: 0, uint32: 0},
+		{num: math.NaN(), int32: 0, uint32: 0},
+		{num: math.Inf(1), int32: 0, uint32: 0},
+		{num: math.Inf(-1), int32: 0, uint32: 0},
+		{num: 1, int32: 1, uint32: 1},
+		{num: -1, int32: -1, uint32: 4294967295},
+		{num: 1.9, int32: 1, uint32: 1},
+		{num: -1.9, int32: -1, uint32: 4294967295},
+		{num: 2147483647, int32: 2147483647, uint32: 2147483647},
+		{num: 2147483648, int32: -2147483648, uint32: 2147483648},
+		{num: -2147483649, int32: 2147483647, uint32: 2147483647},
+		{num: 4294967295, int32: -1, uint32: 4294967295},
+		{num: 4294967296, int32: 0, uint32: 0},
+		{num: 4294967297.5, int32: 1, uint32: 1},
+		{num: 1e21, int32: -559939584, uint32: 3735027712},
+		{num: -1e21, int32: 559939584, uint32: 559939584},
+		{num: 9007199254740993, int32: 0, uint32: 0},
+		{num: math.MaxFloat64, int32: 0, uint32: 0},
+	} {
+		n := types.NewNumber(tc.num)
+		if got := n.ToInt32(); got != tc.int32 {
+			t.Errorf("ToInt32(%s): want %d but got %d",
+				n.ToString(), tc.int32, got)
+		}
+
+		if got := n.ToUint32(); got != tc.uint32 {
+			t.Errorf("ToUint32(%s): want %d but got %d",
+				n.ToString(), tc.uint32, got)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package types
 import (
 	"fmt"
 	"math"
+	"sort"
+	"strconv"
 
 	"github.com/NeowayLabs/abad/internal/utf16"
 )
@@ -13,11 +15,32 @@ type (
 		// Class is the kind of object
 		class         string
 		notExtensible bool
-		props         map[string]*PropertyDescriptor
+
+		// proto is the [[Prototype]] internal property,
+		// Null or an object.
+		proto Value
+
+		props map[string]*PropertyDescriptor
+
+		// keys holds the property names in insertion order
+		// because props has no defined order.
+		keys []string
+
+		// self is the object embedding the DataObject (eg.: an
+		// array) or the DataObject itself. It's the this value
+		// of the getters, toString and valueOf called here and
+		// its [[GetOwnProperty]] is used to look up properties.
+		self Object
 	}
 
-	callable interface {
-		Call(this *DataObject, args []Value) Value
+	// propertyDefiner is an object with a [[DefineOwnProperty]]
+	// internal method.
+	propertyDefiner interface {
+		Object
+
+		DefineOwnPropertyP(
+			name utf16.Str, desc *PropertyDescriptor, throw bool,
+		) (bool, error)
 	}
 )
 
@@ -31,35 +54,19 @@ var (
 	enumAttr     = S("enumerable")
 	cfgAttr      = S("configurable")
 
-	protoAttr    = S("prototype")
 	toStringAttr = S("toString")
 	valueOfAttr  = S("valueOf")
 )
 
-// DefaultPrototypeDesc is a base prototype object that extends Null.
-// The root of the prototype-based type hierarchy.
-func DefaultPrototypeDesc() *PropertyDescriptor {
-	return NewDataPropDesc(Null, true, false, false)
-}
-
 // NewDataObject creates a new DataObject using proto as
-// prototype.
+// prototype, proto must be Null or an object.
 func NewDataObject(proto Value) *DataObject {
-	obj := NewBaseDataObject()
-
-	// obj must extend proto
-	delete(obj.props, "prototype")
-
-	// error ignored because it does not fail if
-	// there's no previous properties.
-	ok, err := obj.DefineOwnPropertyP(protoAttr,
-		NewDataPropDesc(proto, false, true, false), true)
-
-	if !ok {
-		// should never occurs
-		panic(err)
+	obj := &DataObject{
+		class: "Object",
+		proto: proto,
+		props: make(map[string]*PropertyDescriptor),
 	}
-
+	obj.self = obj
 	return obj
 }
 
@@ -67,29 +74,36 @@ func NewDataObject(proto Value) *DataObject {
 //   Object.create(null);
 // This is the root of the prototype chain.
 func NewBaseDataObject() *DataObject {
-	return NewDataObjectP(DefaultPrototypeDesc())
+	return NewDataObject(Null)
 }
 
-// NewDataObjectP creates an object with PropertyDescriptor
-// proto as prototype attribute.
-// The *everything is an object* concept makes
-// impossible to defineOwnProperties work by
-// passing an object to define an object (recursive
-// definition).
-func NewDataObjectP(proto *PropertyDescriptor) *DataObject {
-	obj := &DataObject{
-		class: "object",
-		props: make(map[string]*PropertyDescriptor),
-	}
+// newPrimitiveObject creates the object extending proto that wraps
+// a primitive value of the given class when its properties are
+// accessed.
+func newPrimitiveObject(proto Value, class string) *DataObject {
+	obj := NewDataObject(proto)
+	obj.class = class
+	return obj
+}
 
-	obj.props["prototype"] = proto
+// newFunctionObject creates the object of a function extending proto.
+func newFunctionObject(proto Value) *DataObject {
+	obj := NewDataObject(proto)
+	obj.class = "Function"
 	return obj
 }
 
+// Embed tells that o is embedded in self, the object passed as
+// this value to the getters, toString and valueOf of o.
+func (o *DataObject) Embed(self Object) { o.self = self }
+
 // Class returns the object class
 func (o *DataObject) Class() string       { return o.class }
 func (o *DataObject) NotExtensible() bool { return o.notExtensible }
 
+// Prototype returns the [[Prototype]] of the object.
+func (o *DataObject) Prototype() Value { return o.proto }
+
 // Value interface implementations
 
 // IsFalse SHALL return false for objects.
@@ -212,7 +226,7 @@ func (o *DataObject) ToPropertyDescriptor() *PropertyDescriptor {
 // Get is the default [[Get]] implementation for objects.
 // https://es5.github.io/#x8.12.3
 func (o *DataObject) Get(name utf16.Str) (Value, error) {
-	desc, ok := o.getProperty(name)
+	desc, ok := o.self.getProperty(name)
 	if !ok {
 		return Undefined, nil
 	}
@@ -230,19 +244,27 @@ func (o *DataObject) Get(name utf16.Str) (Value, error) {
 		return Undefined, nil
 	}
 
-	getter, ok := value.(callable)
+	getter, ok := value.(Function)
 	if !ok {
 		panic(fmt.Sprintf("object %s is not callable", getter))
 	}
 
-	return getter.Call(o, []Value{}), nil
+	return getter.Call(o.self, []Value{})
 }
 
 // Put is the default [[Put]] implementation for Object.
+// https://es5.github.io/#x8.12.5
 func (o *DataObject) Put(name utf16.Str, val Value, throw bool) error {
+	return putValue(o, name, val, throw)
+}
+
+// putValue implements [[Put]] on top of the [[DefineOwnProperty]]
+// of o, then objects overriding DefineOwnPropertyP (eg.: arrays)
+// have their own definition invoked.
+func putValue(o propertyDefiner, name utf16.Str, val Value, throw bool) error {
 	if !o.CanPut(name) {
 		if throw {
-			return NewTypeError("can not put data on this object")
+			return NewTypeError("Cannot assign to read only property '%s'", name)
 		}
 
 		return nil
@@ -250,9 +272,10 @@ func (o *DataObject) Put(name utf16.Str, val Value, throw bool) error {
 
 	ownDesc, ok := o.getOwnProperty(name)
 	if ok && ownDesc.IsDataDescriptor() {
-		// TODO(i4k): I'm not sure of this parameters
-		desc := NewDataPropDesc(ownDesc.Value(), true, true, true)
-		_, err := o.DefineOwnPropertyP(name, desc, throw)
+		// only the value changes, the attributes are kept.
+		valueDesc := NewGenericPropDesc()
+		valueDesc.SetValue(val)
+		_, err := o.DefineOwnPropertyP(name, valueDesc, throw)
 		return err
 	}
 
@@ -275,13 +298,13 @@ func (o *DataObject) Put(name utf16.Str, val Value, throw bool) error {
 			panic("setter is undefined for acessor property")
 		}
 
-		setter, ok := set.(callable)
+		setter, ok := set.(Function)
 		if !ok {
 			panic("setter is not a Function")
 		}
 
-		_ = setter.Call(o, []Value{val})
-		return nil
+		_, err := setter.Call(o, []Value{val})
+		return err
 	}
 
 	panic("TODO(i4k): property is not an acessor nor data. Is this a problem?")
@@ -293,11 +316,77 @@ func (o *DataObject) get(name utf16.Str) (*PropertyDescriptor, bool) {
 }
 
 func (o *DataObject) put(name utf16.Str, val *PropertyDescriptor) {
-	o.props[name.String()] = val
+	key := name.String()
+	if _, ok := o.props[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+
+	o.props[key] = val
+}
+
+func (o *DataObject) del(name utf16.Str) {
+	key := name.String()
+	if _, ok := o.props[key]; !ok {
+		return
+	}
+
+	delete(o.props, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// ownKeys returns the own property names in the order V8 uses:
+// array indexes in ascending order and then the other names in
+// insertion order.
+func (o *DataObject) ownKeys() []utf16.Str {
+	var (
+		indexes []uint32
+		names   []utf16.Str
+	)
+
+	for _, key := range o.keys {
+		if idx, ok := arrayIndex(key); ok {
+			indexes = append(indexes, idx)
+			continue
+		}
+
+		names = append(names, S(key))
+	}
+
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i] < indexes[j]
+	})
+
+	keys := make([]utf16.Str, 0, len(o.keys))
+	for _, idx := range indexes {
+		keys = append(keys, S(strconv.FormatUint(uint64(idx), 10)))
+	}
+
+	return append(keys, names...)
+}
+
+// arrayIndex tells if name is an array index, ie. the canonical
+// string of an integer in the range [0, 2^32-2].
+// https://es5.github.io/#x15.4
+func arrayIndex(name string) (uint32, bool) {
+	n, err := strconv.ParseUint(name, 10, 32)
+	if err != nil || n == math.MaxUint32 {
+		return 0, false
+	}
+
+	if strconv.FormatUint(n, 10) != name {
+		return 0, false
+	}
+
+	return uint32(n), true
 }
 
 func (o *DataObject) CanPut(name utf16.Str) bool {
-	desc, ok := o.getOwnProperty(name)
+	desc, ok := o.self.getOwnProperty(name)
 	if ok {
 		if desc.IsAcessorDescriptor() {
 			return !StrictEqual(desc.Set(), Undefined)
@@ -306,15 +395,9 @@ func (o *DataObject) CanPut(name utf16.Str) bool {
 		}
 
 		panic("property is acessor nor data descriptor")
-		return false
-	}
-
-	protodesc, ok := o.getOwnProperty(protoAttr)
-	if !ok {
-		panic("prototype not found")
 	}
 
-	proto := protodesc.Value()
+	proto := o.proto
 	if StrictEqual(proto, Null) {
 		return !o.NotExtensible()
 	}
@@ -337,7 +420,6 @@ func (o *DataObject) CanPut(name utf16.Str) bool {
 	}
 
 	panic("inherited isn't acessor not data descriptor")
-	return false
 }
 
 func (o *DataObject) getOwnProperty(name utf16.Str) (*PropertyDescriptor, bool) {
@@ -349,7 +431,7 @@ func (o *DataObject) getOwnProperty(name utf16.Str) (*PropertyDescriptor, bool)
 }
 
 func (o *DataObject) GetOwnProperty(name utf16.Str) Value {
-	prop, ok := o.get(name)
+	prop, ok := o.self.getOwnProperty(name)
 	if !ok {
 		return Undefined
 	}
@@ -358,27 +440,16 @@ func (o *DataObject) GetOwnProperty(name utf16.Str) Value {
 }
 
 func (o *DataObject) getProperty(name utf16.Str) (*PropertyDescriptor, bool) {
-	prop, ok := o.getOwnProperty(name)
+	prop, ok := o.self.getOwnProperty(name)
 	if ok {
 		return prop, true
 	}
 
-	protodesc, ok := o.getOwnProperty(protoAttr)
+	obj, ok := o.proto.(Object)
 	if !ok {
 		return nil, false
 	}
 
-	if !protodesc.HasValue() {
-		return nil, false
-	}
-
-	protoval := protodesc.Value()
-
-	if protoval.Kind() != KindObject {
-		return nil, false
-	}
-
-	obj := protoval.(Object)
 	return obj.getProperty(name)
 }
 
@@ -392,6 +463,14 @@ func (o *DataObject) GetProperty(name utf16.Str) Value {
 
 func (o *DataObject) DefineOwnProperty(
 	name utf16.Str, desc Value, throw bool,
+) (bool, error) {
+	return defineOwnProperty(o, name, desc, throw)
+}
+
+// defineOwnProperty converts desc into a PropertyDescriptor and
+// defines the property name using the DefineOwnPropertyP of o.
+func defineOwnProperty(
+	o propertyDefiner, name utf16.Str, desc Value, throw bool,
 ) (bool, error) {
 	if desc.Kind() != KindObject {
 		if throw {
@@ -469,7 +548,7 @@ func (o *DataObject) DefineOwnPropertyP(
 			return retOrThrow(NewTypeError("configurable is false"))
 		}
 
-		if descEnum != curEnum {
+		if desc.HasEnum() && descEnum != curEnum {
 			return retOrThrow(
 				NewTypeError("enumerable dont match for configuration disabled"),
 			)
@@ -543,6 +622,64 @@ func (o *DataObject) HasProperty(name utf16.Str) bool {
 	return !StrictEqual(prop, Undefined)
 }
 
+// Delete is the default [[Delete]] implementation for objects.
+// https://es5.github.io/#x8.12.7
+func (o *DataObject) Delete(name utf16.Str, throw bool) (bool, error) {
+	desc, ok := o.getOwnProperty(name)
+	if !ok {
+		return true, nil
+	}
+
+	if desc.Cfg().IsTrue() {
+		o.del(name)
+		return true, nil
+	}
+
+	if throw {
+		return false, NewTypeError("Cannot delete property '%s' of %s",
+			name, o.Class())
+	}
+
+	return false, nil
+}
+
+// Enumerate returns the names of the enumerable properties of the
+// object and of its prototype chain, in the order the for-in
+// statement visits them. A name shadowed by a property of an object
+// earlier in the chain is not returned, even if the shadowing
+// property is not enumerable.
+// https://es5.github.io/#x12.6.4
+func (o *DataObject) Enumerate() []utf16.Str {
+	var (
+		names []utf16.Str
+		obj   = o.self
+	)
+
+	seen := make(map[string]bool)
+
+	for {
+		for _, name := range obj.ownKeys() {
+			if seen[name.String()] {
+				continue
+			}
+
+			seen[name.String()] = true
+
+			desc, _ := obj.getOwnProperty(name)
+			if desc.Enum().IsTrue() {
+				names = append(names, name)
+			}
+		}
+
+		proto, ok := obj.Prototype().(Object)
+		if !ok {
+			return names
+		}
+
+		obj = proto
+	}
+}
+
 // https://es5.github.io/#x8.12.8
 func (o *DataObject) DefaultValue(hint Kind) (Value, error) {
 	if hint == KindString {
@@ -554,17 +691,25 @@ func (o *DataObject) DefaultValue(hint Kind) (Value, error) {
 }
 
 func (o *DataObject) defaultString() (Value, error) {
-	toString, _ := o.Get(toStringAttr)
+	toString, _ := o.self.Get(toStringAttr)
 	if stringify, ok := toString.(Function); ok {
-		str := stringify.Call(o, []Value{})
+		str, err := stringify.Call(o.self, []Value{})
+		if err != nil {
+			return nil, err
+		}
+
 		if IsPrimitive(str) {
 			return str, nil
 		}
 	}
 
-	valueOf, _ := o.Get(valueOfAttr)
-	if valueFunc, ok := valueOf.(callable); ok {
-		val := valueFunc.Call(o, []Value{})
+	valueOf, _ := o.self.Get(valueOfAttr)
+	if valueFunc, ok := valueOf.(Function); ok {
+		val, err := valueFunc.Call(o.self, []Value{})
+		if err != nil {
+			return nil, err
+		}
+
 		if IsPrimitive(val) {
 			return val, nil
 		}
@@ -574,17 +719,25 @@ func (o *DataObject) defaultString() (Value, error) {
 }
 
 func (o *DataObject) defaultNumber() (Value, error) {
-	valueOf, _ := o.Get(valueOfAttr)
-	if valuefunc, ok := valueOf.(callable); ok {
-		val := valuefunc.Call(o, []Value{})
+	valueOf, _ := o.self.Get(valueOfAttr)
+	if valuefunc, ok := valueOf.(Function); ok {
+		val, err := valuefunc.Call(o.self, []Value{})
+		if err != nil {
+			return nil, err
+		}
+
 		if IsPrimitive(val) {
 			return val, nil
 		}
 	}
 
-	tostring, _ := o.Get(toStringAttr)
-	if stringify, ok := tostring.(callable); ok {
-		str := stringify.Call(o, []Value{})
+	tostring, _ := o.self.Get(toStringAttr)
+	if stringify, ok := tostring.(Function); ok {
+		str, err := stringify.Call(o.self, []Value{})
+		if err != nil {
+			return nil, err
+		}
+
 		if IsPrimitive(str) {
 			return str, nil
 		}
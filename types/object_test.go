@@ -24,8 +24,7 @@ var (
 
 func TestBaseObjectExtendsNull(t *testing.T) {
 	obj := types.NewBaseDataObject()
-	proto, err := obj.Get(protoAttr)
-	assert.NoError(t, err, "failed getting proto")
+	proto := obj.Prototype()
 
 	if !types.StrictEqual(proto, types.Null) {
 		t.Fatalf("Raw Object extends Null type")
@@ -36,9 +35,7 @@ func TestNewObjectExtendsProto(t *testing.T) {
 	proto := types.NewBaseDataObject()
 	obj := types.NewDataObject(proto)
 
-	gotproto, err := obj.Get(protoAttr)
-	assert.NoError(t, err, "failed getting prototype")
-
+	gotproto := obj.Prototype()
 	if gotproto.Kind() != types.KindObject {
 		t.Fatalf("got type %s", gotproto.Kind())
 	}
@@ -48,6 +45,35 @@ func TestNewObjectExtendsProto(t *testing.T) {
 		t.Fatalf("%s and %s are not the same prototype",
 			proto, gotobj)
 	}
+
+	// the [[Prototype]] is not a property
+	if obj.HasProperty(protoAttr) {
+		t.Fatalf("object has the %s property", protoAttr)
+	}
+}
+
+func TestObjectInheritsProperties(t *testing.T) {
+	proto := types.NewBaseDataObject()
+	obj := types.NewDataObject(proto)
+	name := S("a")
+
+	err := proto.Put(name, types.NewNumber(1), true)
+	assert.NoError(t, err, "failed to put property in prototype")
+
+	val, err := obj.Get(name)
+	assert.NoError(t, err, "failed to get inherited property")
+	if !types.StrictEqual(val, types.NewNumber(1)) {
+		t.Fatalf("expected 1 but got %s", val)
+	}
+
+	err = obj.Put(name, types.NewNumber(2), true)
+	assert.NoError(t, err, "failed to shadow inherited property")
+
+	val, err = proto.Get(name)
+	assert.NoError(t, err, "failed to get prototype property")
+	if !types.StrictEqual(val, types.NewNumber(1)) {
+		t.Fatalf("prototype property changed to %s", val)
+	}
 }
 
 func TestObjectDefineOwnPropertyDATA(t *testing.T) {
@@ -106,3 +132,129 @@ func testDataDescriptorFail(t *testing.T, obj *types.DataObject, property string
 		t.Fatal("should fail")
 	}
 }
+
+func TestObjectPut(t *testing.T) {
+	obj := types.NewBaseDataObject()
+	name := S("a")
+
+	err := obj.Put(name, types.NewNumber(1), true)
+	assert.NoError(t, err, "failed to put new property")
+
+	err = obj.Put(name, types.NewNumber(2), true)
+	assert.NoError(t, err, "failed to update property")
+
+	val, err := obj.Get(name)
+	assert.NoError(t, err, "failed to get property")
+	if !types.StrictEqual(val, types.NewNumber(2)) {
+		t.Fatalf("expected 2 but got %s", val)
+	}
+
+	// updating the value must keep the attributes
+	desc := obj.GetOwnProperty(name).(*types.DataObject).ToPropertyDescriptor()
+	if !desc.Writable().IsTrue() || !desc.Enum().IsTrue() || !desc.Cfg().IsTrue() {
+		t.Fatalf("attributes changed: %s", desc)
+	}
+}
+
+func TestObjectPutReadOnly(t *testing.T) {
+	obj := types.NewBaseDataObject()
+	name := S("a")
+
+	_, err := obj.DefineOwnPropertyP(name,
+		types.NewDataPropDesc(types.NewNumber(1), false, false, false), true)
+	assert.NoError(t, err, "failed to define property")
+
+	err = obj.Put(name, types.NewNumber(2), false)
+	assert.NoError(t, err, "non strict put must fail silently")
+
+	err = obj.Put(name, types.NewNumber(2), true)
+	assert.EqualErrs(t, types.NewTypeError("Cannot assign to read only property 'a'"),
+		err, "strict put must throw")
+
+	val, err := obj.Get(name)
+	assert.NoError(t, err, "failed to get property")
+	if !types.StrictEqual(val, types.NewNumber(1)) {
+		t.Fatalf("read only property changed to %s", val)
+	}
+}
+
+func TestObjectEnumerate(t *testing.T) {
+	proto := types.NewBaseDataObject()
+	obj := types.NewDataObject(proto)
+
+	put := func(o *types.DataObject, name string, enum bool) {
+		_, err := o.DefineOwnPropertyP(S(name),
+			types.NewDataPropDesc(types.NewNumber(1), true, enum, true), true)
+		assert.NoError(t, err, "failed to define %s", name)
+	}
+
+	put(proto, "p", true)
+	put(proto, "shadowed", true)
+	put(proto, "hidden", true)
+	put(proto, "1", true)
+
+	put(obj, "b", true)
+	put(obj, "10", true)
+	put(obj, "a", true)
+	put(obj, "2", true)
+	put(obj, "01", true)
+	put(obj, "4294967295", true)
+	put(obj, "hidden", false)
+	put(obj, "shadowed", true)
+	put(obj, "removed", true)
+
+	_, err := obj.Delete(S("removed"), true)
+	assert.NoError(t, err, "failed to delete")
+
+	want := []string{
+		"2", "10", "b", "a", "01", "4294967295", "shadowed",
+		"1", "p",
+	}
+
+	got := obj.Enumerate()
+	if len(got) != len(want) {
+		t.Fatalf("expected %v but got %v", want, got)
+	}
+
+	for i, name := range want {
+		if got[i].String() != name {
+			t.Fatalf("expected %v but got %v", want, got)
+		}
+	}
+}
+
+func TestObjectMethodsReceiver(t *testing.T) {
+	var this types.Object
+	self := types.NewBuiltinfn(types.Null,
+		func(obj types.Object, _ []types.Value) (types.Value, error) {
+			this = obj
+			return types.NewNumber(1), nil
+		},
+	)
+
+	for _, obj := range []types.Object{
+		types.NewDataObject(types.Null),
+		types.NewArray(types.Null),
+		types.NewStrictArguments(types.Null, nil),
+		types.NewBuiltinfn(types.Null, nil),
+	} {
+		_, err := obj.DefineOwnProperty(S("a"), types.NewAcessorPropDesc(
+			self, types.Undefined, true, true,
+		).ToObject(), true)
+		assert.NoError(t, err, "define getter on %s", obj.Class())
+		assert.NoError(t, obj.Put(S("valueOf"), self, true),
+			"put valueOf on %s", obj.Class())
+
+		this = nil
+		_, err = obj.Get(S("a"))
+		assert.NoError(t, err, "get a of %s", obj.Class())
+		if this != obj {
+			t.Fatalf("getter of %s called with wrong this", obj.Class())
+		}
+
+		this = nil
+		if obj.ToNumber() != types.NewNumber(1) || this != obj {
+			t.Fatalf("valueOf of %s called with wrong this", obj.Class())
+		}
+	}
+}
@@ -3,6 +3,8 @@ package types
 import (
 	"math"
 	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/NeowayLabs/abad/internal/utf16"
 )
@@ -10,6 +12,15 @@ import (
 type (
 	// String is the primitive string UTF16-encoded type.
 	String utf16.Str
+
+	// StringObject is the object wrapping a primitive string. Its
+	// indexes are not stored, they're read from the string.
+	// https://es5.github.io/#x15.5.5
+	StringObject struct {
+		*DataObject
+
+		value String
+	}
 )
 
 // NewString creates a new string from an UTF-8 encoded str.
@@ -19,8 +30,57 @@ func NewString(str string) String {
 
 func (a String) ToPrimitive(hint Kind) (Value, error) { return a, nil }
 
+// ToObject wraps the string in a String object with no prototype,
+// the interpreter uses the ToObject function instead.
 func (a String) ToObject() (Object, error) {
-	panic("not implemented yet")
+	return NewStringObject(Null, a), nil
+}
+
+// NewStringObject creates the String object of str extending proto.
+func NewStringObject(proto Value, str String) *StringObject {
+	obj := newPrimitiveObject(proto, "String")
+	obj.put(lengthAttr, NewDataPropDesc(
+		NewNumber(float64(len(str))), false, false, false,
+	))
+
+	strobj := &StringObject{
+		DataObject: obj,
+		value:      str,
+	}
+	obj.Embed(strobj)
+	return strobj
+}
+
+func (s *StringObject) ToObject() (Object, error) {
+	return s, nil
+}
+
+// getOwnProperty is the [[GetOwnProperty]] of String objects, an
+// index is a read only property holding the character at it.
+// https://es5.github.io/#x15.5.5.2
+func (s *StringObject) getOwnProperty(name utf16.Str) (*PropertyDescriptor, bool) {
+	desc, ok := s.DataObject.getOwnProperty(name)
+	if ok {
+		return desc, true
+	}
+
+	idx, ok := arrayIndex(name.String())
+	if !ok || idx >= uint32(len(s.value)) {
+		return nil, false
+	}
+
+	return NewDataPropDesc(s.value[idx:idx+1], false, true, false), true
+}
+
+// ownKeys returns the indexes of the string before the other
+// own property names.
+func (s *StringObject) ownKeys() []utf16.Str {
+	keys := make([]utf16.Str, 0, len(s.value))
+	for i := range s.value {
+		keys = append(keys, S(strconv.Itoa(i)))
+	}
+
+	return append(keys, s.DataObject.ownKeys()...)
 }
 
 func (a String) Length() int {
@@ -39,11 +99,39 @@ func (a String) ToBool() Bool {
 	return Bool(a.IsTrue())
 }
 
+// ToNumber converts the string using the StringNumericLiteral
+// grammar. Strings that can't be interpreted as numbers are
+// converted to NaN.
+// https://es5.github.io/#x9.3.1
 func (a String) ToNumber() Number {
-	n, err := strconv.ParseFloat(utf16.Str(a).String(), 64)
-	if err != nil {
+	str := strings.TrimFunc(a.String(), isStrWhiteSpace)
+	if str == "" {
+		return NewNumber(0)
+	}
+
+	if strings.HasPrefix(str, "0x") || strings.HasPrefix(str, "0X") {
+		return hexToNumber(str[2:])
+	}
+
+	unsigned := strings.TrimLeft(str, "+-")
+	if len(str)-len(unsigned) > 1 {
 		return NewNumber(math.NaN())
 	}
+
+	if unsigned == "Infinity" {
+		if str[0] == '-' {
+			return NewNumber(math.Inf(-1))
+		}
+		return NewNumber(math.Inf(1))
+	}
+
+	if !isDecimalLiteral(unsigned) {
+		return NewNumber(math.NaN())
+	}
+
+	// out of range values are rounded to ±Infinity or ±0
+	// by ParseFloat, as required by the spec.
+	n, _ := strconv.ParseFloat(str, 64)
 	return NewNumber(n)
 }
 
@@ -62,3 +150,98 @@ func (a String) Kind() Kind {
 func (a String) Equal(b String) bool {
 	return utf16.Str(a).Equal(utf16.Str(b))
 }
+
+// Less tells if a precedes b when comparing the sequences
+// of code units.
+// https://es5.github.io/#x11.8.5
+func (a String) Less(b String) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+
+	return len(a) < len(b)
+}
+
+// Concat returns the concatenation of strings a and b.
+func (a String) Concat(b String) String {
+	str := make(String, 0, len(a)+len(b))
+	str = append(str, a...)
+	return append(str, b...)
+}
+
+// isDecimalLiteral tells if str is a StrUnsignedDecimalLiteral
+// (except for Infinity).
+// https://es5.github.io/#x9.3.1
+func isDecimalLiteral(str string) bool {
+	digits := func(s string) (int, string) {
+		i := 0
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		return i, s[i:]
+	}
+
+	intlen, rest := digits(str)
+	fraclen := 0
+
+	if len(rest) > 0 && rest[0] == '.' {
+		fraclen, rest = digits(rest[1:])
+	}
+
+	if intlen == 0 && fraclen == 0 {
+		return false
+	}
+
+	if len(rest) > 0 && (rest[0] == 'e' || rest[0] == 'E') {
+		rest = rest[1:]
+		if len(rest) > 0 && (rest[0] == '+' || rest[0] == '-') {
+			rest = rest[1:]
+		}
+
+		var explen int
+		explen, rest = digits(rest)
+		if explen == 0 {
+			return false
+		}
+	}
+
+	return rest == ""
+}
+
+func hexToNumber(str string) Number {
+	if str == "" {
+		return NewNumber(math.NaN())
+	}
+
+	var n float64
+	for _, c := range str {
+		var d int
+		switch {
+		case c >= '0' && c <= '9':
+			d = int(c - '0')
+		case c >= 'a' && c <= 'f':
+			d = int(c-'a') + 10
+		case c >= 'A' && c <= 'F':
+			d = int(c-'A') + 10
+		default:
+			return NewNumber(math.NaN())
+		}
+		n = n*16 + float64(d)
+	}
+
+	return NewNumber(n)
+}
+
+// isStrWhiteSpace tells if r is a WhiteSpace or LineTerminator.
+// https://es5.github.io/#x9.3.1
+func isStrWhiteSpace(r rune) bool {
+	switch r {
+	case '\u0009', '\u000B', '\u000C', '\u0020', '\u00A0', '\uFEFF',
+		'\u000A', '\u000D', '\u2028', '\u2029':
+		return true
+	}
+
+	return unicode.Is(unicode.Zs, r)
+}
@@ -0,0 +1,153 @@
+package types_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NeowayLabs/abad/types"
+	"github.com/madlambda/spells/assert"
+)
+
+func TestStringToNumber(t *testing.T) {
+	for _, tc := range []struct {
+		str  string
+		want float64
+	}{
+		{str: "", want: 0},
+		{str: "   ", want: 0},
+		{str: "0", want: 0},
+		{str: "1", want: 1},
+		{str: "+1", want: 1},
+		{str: "-1", want: -1},
+		{str: " \t\n 42 \n", want: 42},
+		{str: "1.5", want: 1.5},
+		{str: ".5", want: 0.5},
+		{str: "5.", want: 5},
+		{str: "1e3", want: 1000},
+		{str: "1E-3", want: 0.001},
+		{str: "0x10", want: 16},
+		{str: "0XfF", want: 255},
+		{str: "Infinity", want: math.Inf(1)},
+		{str: "-Infinity", want: math.Inf(-1)},
+		{str: "1e1000", want: math.Inf(1)},
+		{str: "0x", want: math.NaN()},
+		{str: "-0x10", want: math.NaN()},
+		{str: "1a", want: math.NaN()},
+		{str: ".", want: math.NaN()},
+		{str: "e5", want: math.NaN()},
+		{str: "1e", want: math.NaN()},
+		{str: "+-1", want: math.NaN()},
+		{str: "inf", want: math.NaN()},
+		{str: "NaN", want: math.NaN()},
+		{str: "1_000", want: math.NaN()},
+	} {
+		got := types.NewString(tc.str).ToNumber()
+		assert.EqualStrings(t,
+			types.NewNumber(tc.want).ToString().String(),
+			got.ToString().String(),
+			"string[%s] to number", tc.str)
+	}
+}
+
+func TestStringToObject(t *testing.T) {
+	obj, err := types.NewString("ab").ToObject()
+	assert.NoError(t, err, "string to object")
+	assert.EqualStrings(t, "String", obj.Class(), "wrapper class")
+
+	for _, tc := range []struct {
+		name string
+		want types.Value
+	}{
+		{name: "length", want: types.NewNumber(2)},
+		{name: "0", want: types.NewString("a")},
+		{name: "1", want: types.NewString("b")},
+		{name: "2", want: types.Undefined},
+		{name: "toString", want: types.Undefined},
+	} {
+		got, err := obj.Get(S(tc.name))
+		assert.NoError(t, err, "get %s", tc.name)
+		if !types.StrictEqual(got, tc.want) {
+			t.Fatalf("expected %s to be %s but got %s", tc.name, tc.want, got)
+		}
+	}
+
+	if obj.CanPut(S("0")) || obj.CanPut(lengthAttr) {
+		t.Fatal("length and indexes must be read only")
+	}
+}
+
+func TestPrimitiveToObject(t *testing.T) {
+	for _, tc := range []struct {
+		val   types.Value
+		class string
+	}{
+		{val: types.NewNumber(1), class: "Number"},
+		{val: types.True, class: "Boolean"},
+	} {
+		obj, err := tc.val.ToObject()
+		assert.NoError(t, err, "%s to object", tc.val)
+		assert.EqualStrings(t, tc.class, obj.Class(), "wrapper class")
+
+		got, err := obj.Get(S("a"))
+		assert.NoError(t, err, "get a")
+		if !types.StrictEqual(got, types.Undefined) {
+			t.Fatalf("expected undefined property but got %s", got)
+		}
+	}
+}
+
+func TestToObject(t *testing.T) {
+	proto := types.NewBaseDataObject()
+	assert.NoError(t, proto.Put(S("a"), types.True, true), "put a")
+
+	for _, tc := range []struct {
+		val   types.Value
+		class string
+	}{
+		{val: types.NewString("ab"), class: "String"},
+		{val: types.NewNumber(1), class: "Number"},
+		{val: types.True, class: "Boolean"},
+	} {
+		obj, err := types.ToObject(tc.val, proto)
+		assert.NoError(t, err, "%s to object", tc.val)
+		assert.EqualStrings(t, tc.class, obj.Class(), "wrapper class")
+
+		if obj.Prototype() != types.Value(proto) {
+			t.Fatalf("wrapper of %s must extend proto", tc.val)
+		}
+
+		got, err := obj.Get(S("a"))
+		assert.NoError(t, err, "get a")
+		if !types.StrictEqual(got, types.True) {
+			t.Fatalf("expected inherited property but got %s", got)
+		}
+	}
+
+	obj := types.NewBaseDataObject()
+	got, err := types.ToObject(obj, proto)
+	assert.NoError(t, err, "object to object")
+	if got != types.Object(obj) {
+		t.Fatal("objects must not be wrapped")
+	}
+}
+
+func TestStringObjectIndexes(t *testing.T) {
+	obj := types.NewStringObject(types.Null, types.NewString("ab"))
+
+	for _, name := range []string{"0", "1"} {
+		if obj.GetOwnProperty(S(name)).Kind() != types.KindObject {
+			t.Fatalf("index %s must be an own property", name)
+		}
+	}
+
+	for _, name := range []string{"2", "01", "-1"} {
+		if obj.GetOwnProperty(S(name)).Kind() != types.KindUndefined {
+			t.Fatalf("%s must not be an own property", name)
+		}
+	}
+
+	names := obj.Enumerate()
+	if len(names) != 2 || names[0].String() != "0" || names[1].String() != "1" {
+		t.Fatalf("only the indexes must be enumerable: %v", names)
+	}
+}
@@ -6,6 +6,11 @@ import (
 )
 
 type (
+	// Evaluator executes the body of the user function f. It's
+	// provided by the interpreter when the function is created and
+	// it holds the lexical environment where f was defined.
+	Evaluator func(f *UserFunction, this Object, args []Value) (Value, error)
+
 	// UserFunction is functions defined by user, ie. they are
 	// defined in ecmascript code.
 	UserFunction struct {
@@ -15,32 +20,120 @@ type (
 
 		params []utf16.Str
 		body   *ast.Program
-		scope  interface{}
+		strict bool
+		eval   Evaluator
+
+		// objectProto is the Object prototype, the [[Prototype]]
+		// of the constructed objects when the prototype property
+		// of the function is not an object.
+		objectProto Value
 	}
 )
 
-func NewUserFunctionPrototype() *UserFunction {
-	return &UserFunction{
+var (
+	lengthAttr      = utf16.S("length")
+	prototypeAttr   = utf16.S("prototype")
+	constructorAttr = utf16.S("constructor")
+)
+
+// NewFunctionPrototype creates the Function prototype object, a
+// function extending objectProto that returns undefined for any
+// arguments. It's the [[Prototype]] of every function object.
+// https://es5.github.io/#x15.3.4
+func NewFunctionPrototype(objectProto Value) *UserFunction {
+	fn := &UserFunction{
 		isFnPrototype: true,
-		DataObject:    NewBaseDataObject(),
+		objectProto:   objectProto,
+		DataObject:    newFunctionObject(objectProto),
 	}
+	fn.Embed(fn)
+	return fn
 }
 
+// NewUserFunction creates a function object extending fnProto, the
+// Function prototype. The prototype property of the function is a
+// new object extending the Object prototype whose constructor
+// property is the function itself.
+// https://es5.github.io/#x13.2
 func NewUserFunction(
-	params []utf16.Str, body *ast.Program, scope interface{}, strict bool,
+	params []utf16.Str, body *ast.Program, strict bool,
+	fnProto *UserFunction, eval Evaluator,
 ) *UserFunction {
-	return &UserFunction{
-		params:     params,
-		body:       body,
-		scope:      scope,
-		DataObject: NewDataObject(NewUserFunctionPrototype()),
+	objectProto := fnProto.objectProto
+	fn := &UserFunction{
+		params:      params,
+		body:        body,
+		strict:      strict,
+		eval:        eval,
+		objectProto: objectProto,
+		DataObject:  newFunctionObject(fnProto),
 	}
+	fn.Embed(fn)
+
+	fn.DefineOwnPropertyP(lengthAttr, NewDataPropDesc(
+		NewNumber(float64(len(params))), false, false, false,
+	), false)
+
+	proto := NewDataObject(objectProto)
+	proto.DefineOwnPropertyP(constructorAttr,
+		NewDataPropDesc(fn, true, false, true), false)
+	fn.DefineOwnPropertyP(prototypeAttr,
+		NewDataPropDesc(proto, true, false, false), false)
+
+	return fn
 }
 
-func (f *UserFunction) Call(this *Object, params []Value) Value {
+// Call is the [[Call]] internal method of user functions.
+// https://es5.github.io/#x13.2.1
+func (f *UserFunction) Call(this Object, args []Value) (Value, error) {
 	if f.isFnPrototype {
-		return Undefined
+		return Undefined, nil
+	}
+
+	return f.eval(f, this, args)
+}
+
+// Construct is the [[Construct]] internal method of user functions,
+// f is called with a new object as this value and the result is the
+// returned object or, if none, the new object.
+// https://es5.github.io/#x13.2.2
+func (f *UserFunction) Construct(args []Value) (Value, error) {
+	if f.isFnPrototype {
+		return nil, NewTypeError("Function.prototype is not a constructor")
+	}
+
+	proto, err := f.Get(prototypeAttr)
+	if err != nil {
+		return nil, err
+	}
+
+	if proto.Kind() != KindObject {
+		proto = f.objectProto
 	}
 
-	return True // todo
+	obj := NewDataObject(proto)
+
+	result, err := f.Call(obj, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Kind() == KindObject {
+		return result, nil
+	}
+
+	return obj, nil
+}
+
+func (f *UserFunction) ToObject() (Object, error) {
+	return f, nil
 }
+
+// Params returns the formal parameters names of f.
+func (f *UserFunction) Params() []utf16.Str { return f.params }
+
+// Body returns the code of f.
+func (f *UserFunction) Body() *ast.Program { return f.body }
+
+// Strict tells if f is strict mode code.
+func (f *UserFunction) Strict() bool { return f.strict }
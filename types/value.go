@@ -34,17 +34,25 @@ type (
 		CanPut(name utf16.Str) bool
 		Put(name utf16.Str, value Value, throw bool) error
 		DefineOwnProperty(n utf16.Str, v Value, throw bool) (bool, error)
+		HasProperty(name utf16.Str) bool
+		Delete(name utf16.Str, throw bool) (bool, error)
+		Enumerate() []utf16.Str
+		GetOwnProperty(name utf16.Str) Value
 
 		// Probably will have other methods like:
-		// GetOwnProperty, etc. but they are not implemented yet.
+		// GetProperty, etc. but they are not implemented yet.
 	}
 
 	// Object is everything that's not a primitive value.
 	Object interface {
+		Value
 		ECMAObject
 
 		Class() string
+		Prototype() Value
 		getProperty(name utf16.Str) (*PropertyDescriptor, bool)
+		getOwnProperty(name utf16.Str) (*PropertyDescriptor, bool)
+		ownKeys() []utf16.Str
 
 		String() string
 	}
@@ -53,7 +61,15 @@ type (
 	Function interface {
 		Object
 
-		Call(this Object, args []Value) Value
+		Call(this Object, args []Value) (Value, error)
+	}
+
+	// Constructor is a Function that creates objects, ie. it has
+	// a Construct method invoked by the new operator.
+	Constructor interface {
+		Function
+
+		Construct(args []Value) (Value, error)
 	}
 )
 
@@ -83,7 +99,6 @@ func (k Kind) String() string {
 	}
 
 	panic("unrecognized type")
-	return ""
 }
 
 // StrictEqual compares values a and b using ECMAScript === (strict) rules.
@@ -120,14 +135,12 @@ func StrictEqual(a, b Value) bool {
 	}
 
 	if akind == KindObject {
-		aobj := a.(*DataObject)
-		bobj := b.(*DataObject)
-		return aobj == bobj // pointer comparison
+		// WHY: objects are always pointers (possibly embedding a
+		// *DataObject), then interface comparison is identity.
+		return a == b
 	}
 
 	panic("strict equal not implemented")
-
-	return false
 }
 
 // IsPrimitive tells if val is a primitive value.
@@ -139,3 +152,33 @@ func IsPrimitive(val Value) bool {
 
 	return false
 }
+
+// ToNumber converts val to a number. Unlike the ToNumber method,
+// the error thrown converting objects to primitive is returned.
+// https://es5.github.io/#x9.3
+func ToNumber(val Value) (Number, error) {
+	prim, err := val.ToPrimitive(KindNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	return prim.ToNumber(), nil
+}
+
+// ToObject converts val to an object, primitive values are wrapped
+// in a new object extending proto, the Object prototype.
+// TODO(i4k): the String, Number and Boolean prototypes are not
+// implemented yet, then the wrappers extend the Object prototype.
+// https://es5.github.io/#x9.9
+func ToObject(val Value, proto Value) (Object, error) {
+	switch v := val.(type) {
+	case String:
+		return NewStringObject(proto, v), nil
+	case Number:
+		return newPrimitiveObject(proto, "Number"), nil
+	case Bool:
+		return newPrimitiveObject(proto, "Boolean"), nil
+	}
+
+	return val.ToObject()
+}
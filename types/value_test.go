@@ -0,0 +1,42 @@
+package types_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NeowayLabs/abad/types"
+)
+
+func TestStrictEqual(t *testing.T) {
+	obj := types.NewBaseDataObject()
+	fn := types.NewBuiltinfn(types.Null, func(types.Object, []types.Value) (types.Value, error) {
+		return types.Undefined, nil
+	})
+
+	for _, tc := range []struct {
+		a, b types.Value
+		want bool
+	}{
+		{a: types.Undefined, b: types.Undefined, want: true},
+		{a: types.Null, b: types.Null, want: true},
+		{a: types.Null, b: types.Undefined, want: false},
+		{a: types.NewNumber(1), b: types.NewNumber(1), want: true},
+		{a: types.NewNumber(0), b: types.NewNumber(math.Copysign(0, -1)), want: true},
+		{a: types.NewNumber(math.NaN()), b: types.NewNumber(math.NaN()), want: false},
+		{a: types.NewNumber(1e-20), b: types.NewNumber(2e-20), want: false},
+		{a: types.NewNumber(1), b: types.NewString("1"), want: false},
+		{a: types.NewString("a"), b: types.NewString("a"), want: true},
+		{a: types.True, b: types.True, want: true},
+		{a: types.True, b: types.False, want: false},
+		{a: obj, b: obj, want: true},
+		{a: obj, b: types.NewBaseDataObject(), want: false},
+		{a: fn, b: fn, want: true},
+		{a: fn, b: obj, want: false},
+	} {
+		got := types.StrictEqual(tc.a, tc.b)
+		if got != tc.want {
+			t.Errorf("%s === %s: want %t but got %t",
+				tc.a.ToString(), tc.b.ToString(), tc.want, got)
+		}
+	}
+}